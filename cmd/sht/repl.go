@@ -289,11 +289,11 @@ func (m *model) onCommand(cmd string) tea.Cmd {
 		return nil
 	}
 
-	res, err := m.runtime.Run(tree)
-	if res != "" {
-		m.appendResult(res)
-	} else {
+	res, err := m.runtime.Execute(tree)
+	if err != nil {
 		m.appendError(err.Error())
+	} else {
+		m.appendResult(m.runtime.Pretty(res, m.width-2))
 	}
 	// println(res)
 
@@ -7,6 +7,8 @@ import (
 
 type Break struct {
 	Token *tokens.Token
+	Label string // optional, the loop to break
+	Value Node   // optional, the value of the broken loop
 }
 
 func (p *Break) GetToken() *tokens.Token {
@@ -14,13 +16,23 @@ func (p *Break) GetToken() *tokens.Token {
 }
 
 func (p *Break) String() string {
-	return fmt.Sprintf("<continue>")
+	if p.Label != "" {
+		return fmt.Sprintf("<break:%s>", p.Label)
+	}
+	return fmt.Sprintf("<break>")
 }
 
 func (p *Break) Children() []Node {
+	if p.Value != nil {
+		return []Node{p.Value}
+	}
 	return []Node{}
 }
 
 func (p *Break) Traverse(level int, fn tfunc) {
 	fn(level, p)
+
+	if p.Value != nil {
+		p.Value.Traverse(level+1, fn)
+	}
 }
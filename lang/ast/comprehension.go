@@ -0,0 +1,56 @@
+package ast
+
+import (
+	"fmt"
+	"sht/lang/tokens"
+)
+
+// ComprehensionClause is either a `for <target> in <iterable>` clause or an
+// `if <condition>` filter.
+type ComprehensionClause struct {
+	Target    Node
+	Iterable  Node
+	Condition Node
+}
+
+func (c *ComprehensionClause) IsFilter() bool {
+	return c.Condition != nil
+}
+
+type Comprehension struct {
+	Token   *tokens.Token
+	Key     Node // only set for map comprehensions
+	Value   Node
+	Clauses []*ComprehensionClause
+}
+
+func (p *Comprehension) GetToken() *tokens.Token {
+	return p.Token
+}
+
+func (p *Comprehension) String() string {
+	return fmt.Sprintf("<comprehension>")
+}
+
+func (p *Comprehension) Children() []Node {
+	children := []Node{}
+	if p.Key != nil {
+		children = append(children, p.Key)
+	}
+	children = append(children, p.Value)
+	for _, clause := range p.Clauses {
+		if clause.IsFilter() {
+			children = append(children, clause.Condition)
+		} else {
+			children = append(children, clause.Target, clause.Iterable)
+		}
+	}
+	return children
+}
+
+func (p *Comprehension) Traverse(level int, fn tfunc) {
+	fn(level, p)
+	for _, child := range p.Children() {
+		child.Traverse(level+1, fn)
+	}
+}
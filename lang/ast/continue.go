@@ -7,6 +7,7 @@ import (
 
 type Continue struct {
 	Token *tokens.Token
+	Label string // optional, the loop to continue
 }
 
 func (p *Continue) GetToken() *tokens.Token {
@@ -14,6 +15,9 @@ func (p *Continue) GetToken() *tokens.Token {
 }
 
 func (p *Continue) String() string {
+	if p.Label != "" {
+		return fmt.Sprintf("<continue:%s>", p.Label)
+	}
 	return fmt.Sprintf("<continue>")
 }
 
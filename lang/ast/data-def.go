@@ -9,8 +9,10 @@ type DataDef struct {
 	Token         *tokens.Token
 	Name          string
 	Likes         []string
+	Protocols     []string // protocols the type declares to implement
 	Properties    []Node
 	Functions     []Node
+	Computed      []Node // read-only properties given with `get`
 	MetaFunctions []Node
 }
 
@@ -23,12 +25,15 @@ func (p *DataDef) String() string {
 	if len(p.Likes) > 0 {
 		name += " like " + strings.Join(p.Likes, ", ")
 	}
+	if len(p.Protocols) > 0 {
+		name += " is " + strings.Join(p.Protocols, ", ")
+	}
 
 	return name
 }
 
 func (p *DataDef) Children() []Node {
-	return append(append(append([]Node{}, p.Properties...), p.Functions...), p.MetaFunctions...)
+	return append(append(append(append([]Node{}, p.Properties...), p.Functions...), p.Computed...), p.MetaFunctions...)
 }
 
 func (p *DataDef) Traverse(level int, fn tfunc) {
@@ -39,6 +44,9 @@ func (p *DataDef) Traverse(level int, fn tfunc) {
 	for _, f := range p.Functions {
 		f.Traverse(level+1, fn)
 	}
+	for _, f := range p.Computed {
+		f.Traverse(level+1, fn)
+	}
 	for _, f := range p.MetaFunctions {
 		f.Traverse(level+1, fn)
 	}
@@ -0,0 +1,25 @@
+package ast
+
+import "sht/lang/tokens"
+
+type Defer struct {
+	Token      *tokens.Token
+	Expression Node
+}
+
+func (p *Defer) GetToken() *tokens.Token {
+	return p.Token
+}
+
+func (p *Defer) String() string {
+	return "<defer>"
+}
+
+func (p *Defer) Children() []Node {
+	return []Node{p.Expression}
+}
+
+func (p *Defer) Traverse(level int, fn tfunc) {
+	fn(level, p)
+	p.Expression.Traverse(level+1, fn)
+}
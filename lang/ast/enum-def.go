@@ -0,0 +1,34 @@
+package ast
+
+import (
+	"sht/lang/tokens"
+)
+
+type EnumVariant struct {
+	Token  *tokens.Token
+	Name   string
+	Fields []string
+}
+
+// EnumDef declares a closed set of variants, which may carry values
+type EnumDef struct {
+	Token    *tokens.Token
+	Name     string
+	Variants []*EnumVariant
+}
+
+func (p *EnumDef) GetToken() *tokens.Token {
+	return p.Token
+}
+
+func (p *EnumDef) String() string {
+	return "<enumdef:" + p.Name + ">"
+}
+
+func (p *EnumDef) Children() []Node {
+	return []Node{}
+}
+
+func (p *EnumDef) Traverse(level int, fn tfunc) {
+	fn(level, p)
+}
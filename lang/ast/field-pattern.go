@@ -0,0 +1,32 @@
+package ast
+
+import (
+	"fmt"
+	"sht/lang/tokens"
+)
+
+// FieldPattern represents the `{a, b, ...rest}` destructuring target, which
+// extracts dict keys or data properties by name
+type FieldPattern struct {
+	Token  *tokens.Token
+	Fields []Node
+}
+
+func (p *FieldPattern) GetToken() *tokens.Token {
+	return p.Token
+}
+
+func (p *FieldPattern) String() string {
+	return fmt.Sprintf("<field pattern>")
+}
+
+func (p *FieldPattern) Children() []Node {
+	return p.Fields
+}
+
+func (p *FieldPattern) Traverse(level int, fn tfunc) {
+	fn(level, p)
+	for _, field := range p.Fields {
+		field.Traverse(level+1, fn)
+	}
+}
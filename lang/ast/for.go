@@ -7,8 +7,10 @@ import (
 
 type For struct {
 	Token     *tokens.Token
+	Label     string
 	Condition Node
 	Body      Node
+	Else      Node // runs when the loop finishes without breaking
 }
 
 func (p *For) GetToken() *tokens.Token {
@@ -16,6 +18,9 @@ func (p *For) GetToken() *tokens.Token {
 }
 
 func (p *For) String() string {
+	if p.Label != "" {
+		return fmt.Sprintf("<for:%s>", p.Label)
+	}
 	return fmt.Sprintf("<for>")
 }
 
@@ -24,6 +29,9 @@ func (p *For) Children() []Node {
 	if p.Condition != nil {
 		values = append(values, p.Condition, p.Body)
 	}
+	if p.Else != nil {
+		values = append(values, p.Else)
+	}
 	return values
 }
 
@@ -33,4 +41,7 @@ func (p *For) Traverse(level int, fn tfunc) {
 		p.Condition.Traverse(level+1, fn)
 	}
 	p.Body.Traverse(level+1, fn)
+	if p.Else != nil {
+		p.Else.Traverse(level+1, fn)
+	}
 }
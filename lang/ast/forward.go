@@ -0,0 +1,41 @@
+package ast
+
+import (
+	"fmt"
+	"sht/lang/tokens"
+)
+
+// Forward represents the `value |> fn` application, which calls the target
+// with the left value as its first argument
+type Forward struct {
+	Token  *tokens.Token
+	Left   Node
+	Target Node
+	ArgFn  Node
+}
+
+func (p *Forward) GetToken() *tokens.Token {
+	return p.Token
+}
+
+func (p *Forward) String() string {
+	return fmt.Sprintf("<forward>")
+}
+
+func (p *Forward) Children() []Node {
+	r := []Node{p.Left}
+	if p.Target != nil {
+		r = append(r, p.Target)
+	}
+	if p.ArgFn != nil {
+		r = append(r, p.ArgFn)
+	}
+	return r
+}
+
+func (p *Forward) Traverse(level int, fn tfunc) {
+	fn(level, p)
+	for _, child := range p.Children() {
+		child.Traverse(level+1, fn)
+	}
+}
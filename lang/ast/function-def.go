@@ -3,12 +3,13 @@ package ast
 import "sht/lang/tokens"
 
 type FunctionDef struct {
-	Token     *tokens.Token
-	Scoped    bool
-	Generator bool
-	Name      string
-	Params    []Node
-	Body      Node
+	Token      *tokens.Token
+	Scoped     bool
+	Generator  bool
+	Name       string
+	Params     []Node
+	Body       Node
+	Decorators []Node // applied from the last to the first
 }
 
 func (p *FunctionDef) GetToken() *tokens.Token {
@@ -20,11 +21,14 @@ func (p *FunctionDef) String() string {
 }
 
 func (p *FunctionDef) Children() []Node {
-	return append(append([]Node{}, p.Params...), p.Body)
+	return append(append(append([]Node{}, p.Decorators...), p.Params...), p.Body)
 }
 
 func (p *FunctionDef) Traverse(level int, fn tfunc) {
 	fn(level, p)
+	for _, decorator := range p.Decorators {
+		decorator.Traverse(level+1, fn)
+	}
 	for _, param := range p.Params {
 		param.Traverse(level+1, fn)
 	}
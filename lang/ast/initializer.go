@@ -45,8 +45,9 @@ func (p *ListInitializer) Traverse(level int, fn tfunc) {
 }
 
 type MapInitializer struct {
-	Token  *tokens.Token
-	Values map[string]Node
+	Token         *tokens.Token
+	Values        map[string]Node
+	Comprehension *Comprehension
 }
 
 func (p *MapInitializer) GetType() InitializerType {
@@ -66,6 +67,9 @@ func (p *MapInitializer) Children() []Node {
 	for _, value := range p.Values {
 		values = append(values, value)
 	}
+	if p.Comprehension != nil {
+		values = append(values, p.Comprehension)
+	}
 	return values
 }
 
@@ -74,4 +78,7 @@ func (p *MapInitializer) Traverse(level int, fn tfunc) {
 	for _, args := range p.Values {
 		args.Traverse(level+1, fn)
 	}
+	if p.Comprehension != nil {
+		p.Comprehension.Traverse(level+1, fn)
+	}
 }
@@ -0,0 +1,30 @@
+package ast
+
+import (
+	"fmt"
+	"sht/lang/tokens"
+)
+
+// KeywordArgument represents a `name=value` argument in a function call
+type KeywordArgument struct {
+	Token *tokens.Token
+	Name  string
+	Value Node
+}
+
+func (p *KeywordArgument) GetToken() *tokens.Token {
+	return p.Token
+}
+
+func (p *KeywordArgument) String() string {
+	return fmt.Sprintf("<keyword argument:%s>", p.Name)
+}
+
+func (p *KeywordArgument) Children() []Node {
+	return []Node{p.Value}
+}
+
+func (p *KeywordArgument) Traverse(level int, fn tfunc) {
+	fn(level, p)
+	p.Value.Traverse(level+1, fn)
+}
@@ -7,6 +7,7 @@ type Parameter struct {
 	Name    string
 	Spread  bool
 	Default Node
+	Pattern Node // destructuring target, when the parameter is not a name
 }
 
 func (p *Parameter) GetToken() *tokens.Token {
@@ -24,11 +25,19 @@ func (p *Parameter) String() string {
 		suffix = " = " + p.Default.String()
 	}
 
-	return "<param:" + prefix + p.Name + suffix + ">"
+	name := p.Name
+	if p.Pattern != nil {
+		name = p.Pattern.String()
+	}
+
+	return "<param:" + prefix + name + suffix + ">"
 }
 
 func (p *Parameter) Children() []Node {
 	r := []Node{}
+	if p.Pattern != nil {
+		r = append(r, p.Pattern)
+	}
 	if p.Default != nil {
 		r = append(r, p.Default)
 	}
@@ -37,7 +46,7 @@ func (p *Parameter) Children() []Node {
 
 func (p *Parameter) Traverse(level int, fn tfunc) {
 	fn(level, p)
-	if p.Default != nil {
-		p.Default.Traverse(level+1, fn)
+	for _, child := range p.Children() {
+		child.Traverse(level+1, fn)
 	}
 }
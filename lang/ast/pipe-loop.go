@@ -10,6 +10,8 @@ type PipeLoop struct {
 	Iterator   Node
 	Assignment Node
 	Body       Node
+	Label      string
+	Else       Node // runs when the loop finishes without breaking
 }
 
 func (p *PipeLoop) GetToken() *tokens.Token {
@@ -17,10 +19,16 @@ func (p *PipeLoop) GetToken() *tokens.Token {
 }
 
 func (p *PipeLoop) String() string {
+	if p.Label != "" {
+		return fmt.Sprintf("<pipe loop:%s>", p.Label)
+	}
 	return fmt.Sprintf("<pipe loop>")
 }
 
 func (p *PipeLoop) Children() []Node {
+	if p.Else != nil {
+		return []Node{p.Iterator, p.Assignment, p.Body, p.Else}
+	}
 	return []Node{p.Iterator, p.Assignment, p.Body}
 }
 
@@ -29,4 +37,7 @@ func (p *PipeLoop) Traverse(level int, fn tfunc) {
 	p.Iterator.Traverse(level+1, fn)
 	p.Assignment.Traverse(level+1, fn)
 	p.Body.Traverse(level+1, fn)
+	if p.Else != nil {
+		p.Else.Traverse(level+1, fn)
+	}
 }
@@ -9,6 +9,7 @@ type Property struct {
 	Token *tokens.Token
 	Name  string
 	Value Node
+	Where Node // condition given with `where`, validating the values set
 }
 
 func (p *Property) GetToken() *tokens.Token {
@@ -20,10 +21,16 @@ func (p *Property) String() string {
 }
 
 func (p *Property) Children() []Node {
+	if p.Where != nil {
+		return []Node{p.Value, p.Where}
+	}
 	return []Node{p.Value}
 }
 
 func (p *Property) Traverse(level int, fn tfunc) {
 	fn(level, p)
 	p.Value.Traverse(level+1, fn)
+	if p.Where != nil {
+		p.Where.Traverse(level+1, fn)
+	}
 }
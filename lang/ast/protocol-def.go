@@ -0,0 +1,43 @@
+package ast
+
+import (
+	"sht/lang/tokens"
+	"strings"
+)
+
+// ProtocolDef declares the functions a data type must implement. Its
+// functions and meta functions have no body.
+type ProtocolDef struct {
+	Token         *tokens.Token
+	Name          string
+	Likes         []string
+	Functions     []Node
+	MetaFunctions []Node
+}
+
+func (p *ProtocolDef) GetToken() *tokens.Token {
+	return p.Token
+}
+
+func (p *ProtocolDef) String() string {
+	name := "<protocol:" + p.Name + ">"
+	if len(p.Likes) > 0 {
+		name += " like " + strings.Join(p.Likes, ", ")
+	}
+
+	return name
+}
+
+func (p *ProtocolDef) Children() []Node {
+	return append(append([]Node{}, p.Functions...), p.MetaFunctions...)
+}
+
+func (p *ProtocolDef) Traverse(level int, fn tfunc) {
+	fn(level, p)
+	for _, f := range p.Functions {
+		f.Traverse(level+1, fn)
+	}
+	for _, f := range p.MetaFunctions {
+		f.Traverse(level+1, fn)
+	}
+}
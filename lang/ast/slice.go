@@ -0,0 +1,40 @@
+package ast
+
+import (
+	"fmt"
+	"sht/lang/tokens"
+)
+
+// Slice represents the `start:stop:step` form inside an indexing. Any of the
+// parts may be nil when omitted.
+type Slice struct {
+	Token *tokens.Token
+	Start Node
+	Stop  Node
+	Step  Node
+}
+
+func (p *Slice) GetToken() *tokens.Token {
+	return p.Token
+}
+
+func (p *Slice) String() string {
+	return fmt.Sprintf("<slice>")
+}
+
+func (p *Slice) Children() []Node {
+	children := []Node{}
+	for _, child := range []Node{p.Start, p.Stop, p.Step} {
+		if child != nil {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+func (p *Slice) Traverse(level int, fn tfunc) {
+	fn(level, p)
+	for _, child := range p.Children() {
+		child.Traverse(level+1, fn)
+	}
+}
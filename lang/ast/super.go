@@ -0,0 +1,24 @@
+package ast
+
+import "sht/lang/tokens"
+
+// Super refers to the parent implementations of the running method
+type Super struct {
+	Token *tokens.Token
+}
+
+func (p *Super) GetToken() *tokens.Token {
+	return p.Token
+}
+
+func (p *Super) String() string {
+	return "<super>"
+}
+
+func (p *Super) Children() []Node {
+	return []Node{}
+}
+
+func (p *Super) Traverse(level int, fn tfunc) {
+	fn(level, p)
+}
@@ -0,0 +1,25 @@
+package ast
+
+import "sht/lang/tokens"
+
+type Try struct {
+	Token      *tokens.Token
+	Expression Node
+}
+
+func (p *Try) GetToken() *tokens.Token {
+	return p.Token
+}
+
+func (p *Try) String() string {
+	return "<try>"
+}
+
+func (p *Try) Children() []Node {
+	return []Node{p.Expression}
+}
+
+func (p *Try) Traverse(level int, fn tfunc) {
+	fn(level, p)
+	p.Expression.Traverse(level+1, fn)
+}
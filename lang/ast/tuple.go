@@ -1,10 +1,14 @@
 package ast
 
-import "sht/lang/tokens"
+import (
+	"sht/lang/tokens"
+	"strings"
+)
 
 type Tuple struct {
 	Token  *tokens.Token
 	Values []Node
+	Names  []string // field names of a named tuple, nil for positional tuples
 }
 
 func (p *Tuple) GetToken() *tokens.Token {
@@ -12,6 +16,9 @@ func (p *Tuple) GetToken() *tokens.Token {
 }
 
 func (p *Tuple) String() string {
+	if p.Names != nil {
+		return "<tuple:" + strings.Join(p.Names, ",") + ">"
+	}
 	return "<tuple>"
 }
 
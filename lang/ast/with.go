@@ -0,0 +1,34 @@
+package ast
+
+import (
+	"fmt"
+	"sht/lang/tokens"
+)
+
+type With struct {
+	Token      *tokens.Token
+	Name       string // optional, the variable receiving the resource
+	Expression Node
+	Body       Node
+}
+
+func (p *With) GetToken() *tokens.Token {
+	return p.Token
+}
+
+func (p *With) String() string {
+	if p.Name == "" {
+		return "<with>"
+	}
+	return fmt.Sprintf("<with:%s>", p.Name)
+}
+
+func (p *With) Children() []Node {
+	return []Node{p.Expression, p.Body}
+}
+
+func (p *With) Traverse(level int, fn tfunc) {
+	fn(level, p)
+	p.Expression.Traverse(level+1, fn)
+	p.Body.Traverse(level+1, fn)
+}
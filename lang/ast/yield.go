@@ -5,6 +5,7 @@ import "sht/lang/tokens"
 type Yield struct {
 	Token      *tokens.Token
 	Expression Node
+	From       bool // delegates to the iterator in `yield from expr`
 }
 
 func (p *Yield) GetToken() *tokens.Token {
@@ -12,6 +13,9 @@ func (p *Yield) GetToken() *tokens.Token {
 }
 
 func (p *Yield) String() string {
+	if p.From {
+		return "<yield:from>"
+	}
 	return "<yield>"
 }
 
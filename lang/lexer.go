@@ -26,10 +26,16 @@ var keywords = []string{
 	"return",
 	"raise",
 	"yield",
+	"try",
+	"defer",
+	"with",
 
 	"on",
 	"fn",
 	"data",
+	"enum",
+	"protocol",
+	"super",
 
 	"module",
 	"use",
@@ -583,6 +589,10 @@ func (l *Lexer) parseNextToken() *tokens.Token {
 		case c.Is('@'):
 			token = tokens.CreateToken(tokens.At, "@", c.Line, c.Column)
 			l.EatChar()
+		case c.Is('|') && nr == '>':
+			token = tokens.CreateToken(tokens.Pipe, "|>", c.Line, c.Column)
+			l.EatChar()
+			l.EatChar()
 		case c.Is('|'):
 			token = tokens.CreateToken(tokens.Pipe, "|", c.Line, c.Column)
 			l.EatChar()
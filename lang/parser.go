@@ -101,8 +101,10 @@ type Parser struct {
 	inMetaDef   bool
 
 	// function content control
-	hasReturn bool
-	hasYield  bool
+	hasYield bool
+
+	// labels of the loops being parsed
+	labels []string
 }
 
 func CreateParser() *Parser {
@@ -123,6 +125,8 @@ func CreateParser() *Parser {
 	p.prefixFns[tokens.Lparen] = p.parsePrefixParenthesis
 	p.prefixFns[tokens.Identifier] = p.parsePrefixIdentifier
 	p.prefixFns[tokens.Spread] = p.parsePrefixSpread
+	p.prefixFns[tokens.Lbrace] = p.parsePrefixBrace
+	p.prefixFns[tokens.At] = p.parsePrefixAt
 
 	p.infixFns[tokens.Operator] = p.parseInfixOperator
 	p.infixFns[tokens.Keyword] = p.parseInfixKeyword
@@ -209,7 +213,7 @@ func (p *Parser) parseBlock() ast.Node {
 	block := &ast.Block{}
 
 	t := p.lexer.PeekToken()
-	braced := t.Is(tokens.Lbrace)
+	braced := t.Is(tokens.Lbrace) && !p.isFieldPattern(tokens.Assignment)
 	if braced {
 		p.lexer.EatToken()
 	}
@@ -244,7 +248,7 @@ func (p *Parser) parseStatement() ast.Node {
 		p.lexer.EatToken()
 		node = nil
 
-	} else if cur.Is(tokens.Lbrace) {
+	} else if cur.Is(tokens.Lbrace) && !p.isFieldPattern(tokens.Assignment) {
 		node = p.parseBlock()
 
 	} else if cur.Is(tokens.Keyword) &&
@@ -253,6 +257,9 @@ func (p *Parser) parseStatement() ast.Node {
 		cur.Literal == "yield" {
 		node = p.parseReturn()
 
+	} else if cur.Is(tokens.Identifier) && p.lexer.PeekTokenN(1).Is(tokens.Colon) && isLoopKeyword(p.lexer.PeekTokenN(2)) {
+		node = p.parseLabeledLoop()
+
 	} else if cur.Is(tokens.Keyword) && cur.Literal == "pipe" {
 		node = p.parsePipeLoop()
 
@@ -262,6 +269,12 @@ func (p *Parser) parseStatement() ast.Node {
 	} else if cur.Is(tokens.Keyword) && cur.Literal == "if" {
 		node = p.parseIf()
 
+	} else if cur.Is(tokens.Keyword) && cur.Literal == "defer" {
+		node = p.parseDefer()
+
+	} else if cur.Is(tokens.Keyword) && cur.Literal == "with" {
+		node = p.parseWith()
+
 		// } else if cur.Is(tokens.Keyword) && (cur.Literal == "let" || cur.Literal == "const") {
 		// 	node = p.parseDeclaration()
 
@@ -316,30 +329,31 @@ func (p *Parser) parseReturn() ast.Node {
 	cur := p.lexer.PeekToken()
 	p.lexer.EatToken()
 
+	// `yield from iter`, where `from` is only special after yield
+	from := false
+	nxt := p.lexer.PeekToken()
+	if cur.Literal == "yield" && nxt.Is(tokens.Identifier) && nxt.Literal == "from" && !isEndOfStatement(p.lexer.PeekTokenN(1)) {
+		p.lexer.EatToken()
+		from = true
+	}
+
 	exp := p.parseExpressionTuple()
 	exp = p.checkPipe(exp)
 
 	switch cur.Literal {
 	case "return":
-		if p.hasYield {
-			p.RegisterError(fmt.Sprintf("can't have return and yield in the same function"), cur)
-		}
-		p.hasReturn = true
-
 		return &ast.Return{
 			Token:      cur,
 			Expression: exp,
 		}
 
 	case "yield":
-		if p.hasReturn {
-			p.RegisterError(fmt.Sprintf("can't have return and yield in the same function"), cur)
-		}
 		p.hasYield = true
 
 		return &ast.Yield{
 			Token:      cur,
 			Expression: exp,
+			From:       from,
 		}
 
 	case "raise":
@@ -357,23 +371,93 @@ func (p *Parser) parseForControl() ast.Node {
 	cur := p.lexer.PeekToken()
 	p.lexer.EatToken()
 
+	// `break label` and `continue label`, where label must be an enclosing
+	// loop, otherwise it is the value of the break
+	label := ""
+	nxt := p.lexer.PeekToken()
+	if nxt.Is(tokens.Identifier) && slices.Contains(p.labels, nxt.Literal) {
+		p.lexer.EatToken()
+		label = nxt.Literal
+	}
+
 	switch cur.Literal {
 	case "continue":
 		return &ast.Continue{
 			Token: cur,
+			Label: label,
 		}
 
 	case "break":
-		return &ast.Break{
+		node := &ast.Break{
 			Token: cur,
+			Label: label,
+		}
+
+		nxt = p.lexer.PeekToken()
+		if !isEndOfStatement(nxt) && !(nxt.Is(tokens.Keyword) && nxt.Literal == "else") {
+			node.Value = p.parseExpressionTuple()
 		}
 
+		return node
+
 	default:
 		p.RegisterError(fmt.Sprintf("invalid for control token '%s'", cur.Literal), cur)
 		return nil
 	}
 }
 
+// Parses `label: for ...` and `label: pipe ...`, where the label can be
+// used by break and continue in nested loops
+func (p *Parser) parseLabeledLoop() ast.Node {
+	cur := p.lexer.PeekToken()
+	p.lexer.EatToken()
+	p.lexer.EatToken()
+
+	if slices.Contains(p.labels, cur.Literal) {
+		p.RegisterError(fmt.Sprintf("duplicated loop label '%s'", cur.Literal), cur)
+		return nil
+	}
+
+	p.labels = append(p.labels, cur.Literal)
+	defer func() { p.labels = p.labels[:len(p.labels)-1] }()
+
+	switch node := p.parseLoop().(type) {
+	case *ast.For:
+		node.Label = cur.Literal
+		return node
+
+	case *ast.PipeLoop:
+		node.Label = cur.Literal
+		return node
+	}
+
+	return nil
+}
+
+func (p *Parser) parseLoop() ast.Node {
+	if p.lexer.PeekToken().Literal == "pipe" {
+		return p.parsePipeLoop()
+	}
+
+	return p.parseFor()
+}
+
+// Parses the optional `else` branch of loops
+func (p *Parser) parseLoopElse() ast.Node {
+	cur := p.lexer.PeekToken()
+	if !cur.Is(tokens.Keyword) || cur.Literal != "else" {
+		return nil
+	}
+
+	p.lexer.EatToken()
+	p.eatNewLines()
+	if !p.Expect(tokens.Lbrace) {
+		return nil
+	}
+
+	return p.parseBlock()
+}
+
 func (p *Parser) parsePipeLoop() ast.Node {
 	ini := p.lexer.PeekToken()
 	p.lexer.EatToken()
@@ -403,7 +487,11 @@ func (p *Parser) parsePipeLoop() ast.Node {
 
 	p.inCondition = true
 	p.inPipeLoop = true
-	pipe.Assignment = p.parseExpressionTuple()
+	if p.isFieldPattern(tokens.Lbrace, tokens.Newline) {
+		pipe.Assignment = p.parseFieldPattern()
+	} else {
+		pipe.Assignment = p.parseExpressionTuple()
+	}
 	p.inCondition = false
 	p.inPipeLoop = false
 
@@ -420,6 +508,7 @@ func (p *Parser) parsePipeLoop() ast.Node {
 
 	p.eatNewLines()
 	pipe.Body = p.parseBlock()
+	pipe.Else = p.parseLoopElse()
 	return pipe
 }
 
@@ -446,6 +535,61 @@ func (p *Parser) parseFor() ast.Node {
 	}
 
 	p.eatNewLines()
+	node.Body = p.parseBlock()
+	node.Else = p.parseLoopElse()
+	return node
+}
+
+func (p *Parser) parseDefer() ast.Node {
+	cur := p.lexer.PeekToken()
+	p.lexer.EatToken()
+
+	exp := p.parseStatement()
+	if exp == nil {
+		p.RegisterError(fmt.Sprintf("invalid defer expression"), cur)
+		return nil
+	}
+
+	return &ast.Defer{
+		Token:      cur,
+		Expression: exp,
+	}
+}
+
+// Parses `with name := expr { ... }` or `with expr { ... }`
+func (p *Parser) parseWith() ast.Node {
+	ini := p.lexer.PeekToken()
+	p.lexer.EatToken()
+
+	node := &ast.With{
+		Token: ini,
+	}
+
+	cur := p.lexer.PeekToken()
+	nxt := p.lexer.PeekTokenN(1)
+	if cur.Is(tokens.Identifier) && nxt.Is(tokens.Assignment) {
+		if nxt.Literal != ":=" {
+			p.RegisterError(fmt.Sprintf("expected ':=' in with expression, got '%s'", nxt.Literal), nxt)
+			return nil
+		}
+
+		node.Name = cur.Literal
+		p.lexer.EatToken()
+		p.lexer.EatToken()
+	}
+
+	p.inCondition = true
+	node.Expression = p.parseSingleExpression(order.Lowest)
+	p.inCondition = false
+	if node.Expression == nil {
+		p.RegisterError(fmt.Sprintf("invalid with expression"), p.lexer.PeekToken())
+		return nil
+	}
+
+	if !p.Expect(tokens.Lbrace) {
+		return nil
+	}
+
 	node.Body = p.parseBlock()
 	return node
 }
@@ -633,6 +777,14 @@ func (p *Parser) assertAssignmentTargets(t ast.Node) (ast.Node, string) {
 	case *ast.SpreadIn:
 		return p.assertAssignmentTargets(t.Target)
 
+	case *ast.FieldPattern:
+		for _, v := range t.Fields {
+			r, err := p.assertAssignmentTargets(v)
+			if err != "" {
+				return r, err
+			}
+		}
+
 	case nil:
 		return t, "invalid left-side assignment"
 
@@ -643,6 +795,110 @@ func (p *Parser) assertAssignmentTargets(t ast.Node) (ast.Node, string) {
 	return t, ""
 }
 
+// Reports if the next tokens form a field pattern such as `{a, ...rest}`
+// followed by one of the given token types
+func (p *Parser) isFieldPattern(follows ...tokens.Type) bool {
+	if !p.lexer.PeekToken().Is(tokens.Lbrace) {
+		return false
+	}
+
+	for i := 1; ; i++ {
+		t := p.lexer.PeekTokenN(i)
+		switch {
+		case t.Is(tokens.Identifier), t.Is(tokens.Comma), t.Is(tokens.Spread), t.Is(tokens.Newline):
+			continue
+
+		case t.Is(tokens.Rbrace):
+			return slices.Contains(follows, p.lexer.PeekTokenN(i+1).Type)
+
+		default:
+			return false
+		}
+	}
+}
+
+func (p *Parser) parseFieldPattern() ast.Node {
+	ini := p.lexer.EatToken()
+	pattern := &ast.FieldPattern{
+		Token: ini,
+	}
+
+	hasSpread := false
+	p.eatNewLines()
+	cur := p.lexer.PeekToken()
+	for !cur.Is(tokens.Rbrace) {
+		spread := cur
+		if spread.Is(tokens.Spread) {
+			if hasSpread {
+				p.RegisterError(fmt.Sprintf("field patterns can have only one spread operator"), spread)
+				return nil
+			}
+
+			hasSpread = true
+			p.lexer.EatToken()
+		}
+
+		if !p.Expect(tokens.Identifier) {
+			return nil
+		}
+
+		cur = p.lexer.EatToken()
+		var field ast.Node = &ast.Identifier{
+			Token: cur,
+			Value: cur.Literal,
+		}
+
+		if spread.Is(tokens.Spread) {
+			field = &ast.SpreadIn{
+				Token:  spread,
+				Target: field,
+			}
+		}
+		pattern.Fields = append(pattern.Fields, field)
+
+		p.eatNewLines()
+		if p.lexer.PeekToken().Is(tokens.Comma) {
+			p.lexer.EatToken()
+			p.eatNewLines()
+		}
+		cur = p.lexer.PeekToken()
+	}
+
+	p.lexer.EatToken()
+	return pattern
+}
+
+func (p *Parser) parsePrefixBrace() ast.Node {
+	return p.parseFieldPattern()
+}
+
+// Parses a destructuring parameter, either a tuple or a field pattern
+func (p *Parser) parseParameterPattern() ast.Node {
+	var pattern ast.Node
+	if p.lexer.PeekToken().Is(tokens.Lbrace) {
+		pattern = p.parseFieldPattern()
+	} else {
+		pattern = p.parsePrefixParenthesis()
+	}
+
+	if pattern == nil {
+		return nil
+	}
+
+	if _, ok := pattern.(*ast.Identifier); ok {
+		p.RegisterError(fmt.Sprintf("invalid parameter pattern"), pattern.GetToken())
+		return nil
+	}
+
+	inv, err := p.assertAssignmentTargets(pattern)
+	if err != "" {
+		p.RegisterError("invalid parameter pattern: "+err, inv.GetToken())
+		return nil
+	}
+
+	return pattern
+}
+
 func (p *Parser) parseBoolean() ast.Node {
 	t := p.lexer.EatToken()
 	return &ast.Boolean{
@@ -686,10 +942,10 @@ func (p *Parser) parseFunctionDef() ast.Node {
 		return nil
 	}
 
-	hr := p.hasReturn
 	hy := p.hasYield
-	p.hasReturn = false
 	p.hasYield = false
+	labels := p.labels
+	p.labels = nil
 	if cur.Is(tokens.Lbrace) {
 		fn.Body = p.parseBlock()
 	}
@@ -698,8 +954,8 @@ func (p *Parser) parseFunctionDef() ast.Node {
 		fn.Generator = true
 	}
 
-	p.hasReturn = hr
 	p.hasYield = hy
+	p.labels = labels
 
 	return fn
 }
@@ -724,26 +980,13 @@ func (p *Parser) parseDataDef() ast.Node {
 	cur = p.lexer.PeekToken()
 	if cur.Literal == "like" {
 		p.lexer.EatToken()
+		dd.Likes = p.parseNameList()
+	}
 
-		likes := []string{}
-		for {
-			p.eatNewLines()
-			cur = p.lexer.PeekToken()
-			if !cur.Is(tokens.Identifier) {
-				break
-			}
-
-			likes = append(likes, cur.Literal)
-			p.lexer.EatToken()
-
-			cur = p.lexer.PeekToken()
-			if !cur.Is(tokens.Comma) {
-				break
-			}
-			p.lexer.EatToken()
-		}
-
-		dd.Likes = likes
+	cur = p.lexer.PeekToken()
+	if cur.Is(tokens.Keyword) && cur.Literal == "is" {
+		p.lexer.EatToken()
+		dd.Protocols = p.parseNameList()
 	}
 
 	cur = p.lexer.PeekToken()
@@ -756,7 +999,35 @@ func (p *Parser) parseDataDef() ast.Node {
 	p.eatNewLines()
 	cur = p.lexer.PeekToken()
 	for !cur.Is(tokens.Rbrace) {
-		if cur.Is(tokens.Identifier) {
+		var decorators []ast.Node
+		if cur.Is(tokens.At) {
+			decorators = p.parseDecorators()
+			if decorators == nil {
+				return nil
+			}
+
+			cur = p.lexer.PeekToken()
+			if cur.Literal != "fn" && cur.Literal != "on" {
+				p.RegisterError(fmt.Sprintf("decorators must be followed by a function definition"), cur)
+				return nil
+			}
+		}
+
+		if cur.Is(tokens.Identifier) && cur.Literal == "get" && p.lexer.PeekTokenN(1).Is(tokens.Identifier) {
+			fn := p.parseFunctionDef()
+			if fn == nil {
+				return nil
+			}
+
+			fnd := fn.(*ast.FunctionDef)
+			if len(fnd.Params) != 1 || fnd.Params[0].(*ast.Parameter).Name != "this" {
+				p.RegisterError(fmt.Sprintf("computed property '%s' must have only a 'this' parameter", fnd.Name), fn.GetToken())
+				return nil
+			}
+
+			dd.Computed = append(dd.Computed, fn)
+
+		} else if cur.Is(tokens.Identifier) {
 			property := &ast.Property{
 				Token: cur,
 			}
@@ -771,6 +1042,16 @@ func (p *Parser) parseDataDef() ast.Node {
 
 			p.lexer.EatToken()
 			property.Value = p.parseExpressionTuple()
+
+			cur = p.lexer.PeekToken()
+			if cur.Is(tokens.Identifier) && cur.Literal == "where" {
+				p.lexer.EatToken()
+				property.Where = p.parseSingleExpression(order.Lowest)
+				if property.Where == nil {
+					return nil
+				}
+			}
+
 			dd.Properties = append(dd.Properties, property)
 
 		} else if cur.Literal == "fn" {
@@ -778,6 +1059,7 @@ func (p *Parser) parseDataDef() ast.Node {
 			if fn == nil {
 				return nil
 			}
+			fn.(*ast.FunctionDef).Decorators = decorators
 			dd.Functions = append(dd.Functions, fn)
 
 		} else if cur.Literal == "on" {
@@ -789,6 +1071,7 @@ func (p *Parser) parseDataDef() ast.Node {
 			}
 
 			fnd := fn.(*ast.FunctionDef)
+			fnd.Decorators = decorators
 			name := fnd.Name
 			if !meta.IsValid(name) {
 				p.RegisterError(fmt.Sprintf("invalid meta function name '%s'", name), fn.GetToken())
@@ -822,48 +1105,243 @@ func (p *Parser) parseDataDef() ast.Node {
 	return dd
 }
 
-func (p *Parser) parseParameters() []ast.Node {
-	braced := false
-	cur := p.lexer.PeekToken()
-	if cur.Is(tokens.Lparen) {
-		braced = true
+// Parses the comma separated identifiers following `like` and `is` in type
+// definitions
+func (p *Parser) parseNameList() []string {
+	names := []string{}
+	for {
+		p.eatNewLines()
+		cur := p.lexer.PeekToken()
+		if !cur.Is(tokens.Identifier) {
+			break
+		}
+
+		names = append(names, cur.Literal)
+		p.lexer.EatToken()
+
+		cur = p.lexer.PeekToken()
+		if !cur.Is(tokens.Comma) {
+			break
+		}
 		p.lexer.EatToken()
 	}
 
-	params := []ast.Node{}
+	return names
+}
 
-	hasSpread := false
-	hasDefault := false
-	cur = p.lexer.PeekToken()
-	for !cur.Is(tokens.Rparen) && !cur.Is(tokens.Colon) {
-		p.eatNewLines()
+// Parses `protocol Name like Other { fn name(this); on iter(this) }`, where
+// the functions are declared without body
+func (p *Parser) parseProtocolDef() ast.Node {
+	cur := p.lexer.EatToken()
+	if !p.Expect(tokens.Identifier) {
+		p.RegisterError(fmt.Sprintf("invalid protocol definition, missing name"), p.lexer.PeekToken())
+		return nil
+	}
 
-		param := &ast.Parameter{}
+	pd := &ast.ProtocolDef{
+		Token: cur,
+		Name:  p.lexer.EatToken().Literal,
+	}
 
-		cur = p.lexer.PeekToken()
-		if cur.Is(tokens.Spread) {
-			p.lexer.EatToken()
-			param.Spread = true
+	cur = p.lexer.PeekToken()
+	if cur.Literal == "like" {
+		p.lexer.EatToken()
+		pd.Likes = p.parseNameList()
+	}
 
-			if hasSpread {
-				p.RegisterError(fmt.Sprintf("parameters can have only one spread operator"), cur)
-				return nil
-			}
+	if !p.Expect(tokens.Lbrace) {
+		p.RegisterError(fmt.Sprintf("invalid protocol definition"), p.lexer.PeekToken())
+		return nil
+	}
 
-			hasSpread = true
+	p.lexer.EatToken()
+	p.eatNewLines()
+	cur = p.lexer.PeekToken()
+	for !cur.Is(tokens.Rbrace) {
+		if cur.Literal != "fn" && cur.Literal != "on" {
+			p.RegisterError(fmt.Sprintf("invalid protocol definition, expecting 'fn' or 'on', got '%s'", cur.Literal), cur)
+			return nil
 		}
 
-		if !p.Expect(tokens.Identifier) {
-			p.RegisterError(fmt.Sprintf("invalid parameter token '%s'", cur.Literal), cur)
+		isMeta := cur.Literal == "on"
+		p.lexer.EatToken()
+
+		name := p.lexer.PeekToken()
+		if !name.Is(tokens.Identifier) && !(isMeta && name.Is(tokens.Keyword)) {
+			p.RegisterError(fmt.Sprintf("invalid protocol function name '%s'", name.Literal), name)
 			return nil
 		}
-
-		cur = p.lexer.PeekToken()
-		param.Token = cur
-		param.Name = cur.Literal
 		p.lexer.EatToken()
 
-		cur = p.lexer.PeekToken()
+		if isMeta && !meta.IsValid(name.Literal) {
+			p.RegisterError(fmt.Sprintf("invalid meta function name '%s'", name.Literal), name)
+			return nil
+		}
+
+		fn := &ast.FunctionDef{
+			Token: cur,
+			Name:  name.Literal,
+		}
+		if p.lexer.PeekToken().Is(tokens.Lparen) {
+			fn.Params = p.parseParameters()
+		}
+
+		if isMeta {
+			pd.MetaFunctions = append(pd.MetaFunctions, fn)
+		} else {
+			pd.Functions = append(pd.Functions, fn)
+		}
+
+		cur = p.lexer.PeekToken()
+		if !cur.Is(tokens.Newline) && !cur.Is(tokens.Semicolon) && !cur.Is(tokens.Rbrace) {
+			p.RegisterError(fmt.Sprintf("invalid protocol definition, unexpected '%s'", cur.Literal), cur)
+			return nil
+		}
+
+		for cur.Is(tokens.Newline) || cur.Is(tokens.Semicolon) {
+			p.lexer.EatToken()
+			cur = p.lexer.PeekToken()
+		}
+	}
+	p.lexer.EatToken()
+
+	return pd
+}
+
+// Parses `enum Name { Variant(field, other), Unit }`, with the variants
+// separated by commas or new lines
+func (p *Parser) parseEnumDef() ast.Node {
+	cur := p.lexer.EatToken()
+	if !p.Expect(tokens.Identifier) {
+		p.RegisterError(fmt.Sprintf("invalid enum definition, missing name"), p.lexer.PeekToken())
+		return nil
+	}
+
+	ed := &ast.EnumDef{
+		Token: cur,
+		Name:  p.lexer.EatToken().Literal,
+	}
+
+	if !p.Expect(tokens.Lbrace) {
+		p.RegisterError(fmt.Sprintf("invalid enum definition"), p.lexer.PeekToken())
+		return nil
+	}
+	p.lexer.EatToken()
+
+	for {
+		cur = p.lexer.PeekToken()
+		for cur.Is(tokens.Newline) || cur.Is(tokens.Comma) {
+			p.lexer.EatToken()
+			cur = p.lexer.PeekToken()
+		}
+
+		if cur.Is(tokens.Rbrace) {
+			break
+		}
+
+		if !cur.Is(tokens.Identifier) {
+			p.RegisterError(fmt.Sprintf("invalid enum variant '%s'", cur.Literal), cur)
+			return nil
+		}
+		p.lexer.EatToken()
+
+		variant := &ast.EnumVariant{
+			Token:  cur,
+			Name:   cur.Literal,
+			Fields: []string{},
+		}
+
+		if p.lexer.PeekToken().Is(tokens.Lparen) {
+			p.lexer.EatToken()
+			for {
+				field := p.lexer.PeekToken()
+				if field.Is(tokens.Rparen) {
+					break
+				}
+
+				if !field.Is(tokens.Identifier) {
+					p.RegisterError(fmt.Sprintf("invalid field '%s' in enum variant '%s'", field.Literal, variant.Name), field)
+					return nil
+				}
+				p.lexer.EatToken()
+				variant.Fields = append(variant.Fields, field.Literal)
+
+				if !p.lexer.PeekToken().Is(tokens.Comma) {
+					break
+				}
+				p.lexer.EatToken()
+			}
+
+			if !p.Expect(tokens.Rparen) {
+				return nil
+			}
+			p.lexer.EatToken()
+		}
+
+		ed.Variants = append(ed.Variants, variant)
+
+		cur = p.lexer.PeekToken()
+		if !cur.Is(tokens.Comma) && !cur.Is(tokens.Newline) && !cur.Is(tokens.Rbrace) {
+			p.RegisterError(fmt.Sprintf("invalid enum definition, unexpected '%s'", cur.Literal), cur)
+			return nil
+		}
+	}
+	p.lexer.EatToken()
+
+	return ed
+}
+
+func (p *Parser) parseParameters() []ast.Node {
+	braced := false
+	cur := p.lexer.PeekToken()
+	if cur.Is(tokens.Lparen) {
+		braced = true
+		p.lexer.EatToken()
+	}
+
+	params := []ast.Node{}
+
+	hasSpread := false
+	hasDefault := false
+	cur = p.lexer.PeekToken()
+	for !cur.Is(tokens.Rparen) && !cur.Is(tokens.Colon) {
+		p.eatNewLines()
+
+		param := &ast.Parameter{}
+
+		cur = p.lexer.PeekToken()
+		if cur.Is(tokens.Spread) {
+			p.lexer.EatToken()
+			param.Spread = true
+
+			if hasSpread {
+				p.RegisterError(fmt.Sprintf("parameters can have only one spread operator"), cur)
+				return nil
+			}
+
+			hasSpread = true
+		}
+
+		cur = p.lexer.PeekToken()
+		if !param.Spread && (cur.Is(tokens.Lparen) || cur.Is(tokens.Lbrace)) {
+			param.Token = cur
+			param.Pattern = p.parseParameterPattern()
+			if param.Pattern == nil {
+				return nil
+			}
+
+		} else {
+			if !p.Expect(tokens.Identifier) {
+				p.RegisterError(fmt.Sprintf("invalid parameter token '%s'", cur.Literal), cur)
+				return nil
+			}
+
+			param.Token = cur
+			param.Name = cur.Literal
+			p.lexer.EatToken()
+		}
+
+		cur = p.lexer.PeekToken()
 		if cur.Is(tokens.Assignment) && cur.Literal == "=" {
 			if param.Spread {
 				p.RegisterError(fmt.Sprintf("spread parameters can't have default values"), cur)
@@ -885,7 +1363,7 @@ func (p *Parser) parseParameters() []ast.Node {
 
 		params = append(params, param)
 
-		if !p.Expect(tokens.Identifier, tokens.Rparen, tokens.Newline, tokens.Spread, tokens.Colon) {
+		if !p.Expect(tokens.Identifier, tokens.Rparen, tokens.Newline, tokens.Spread, tokens.Colon, tokens.Lparen, tokens.Lbrace) {
 			p.RegisterError(fmt.Sprintf("invalid end of parameter token '%s'", cur.Literal), cur)
 			return nil
 		}
@@ -977,13 +1455,60 @@ func (p *Parser) parseExpressionList() []ast.Node {
 	return args
 }
 
+// Parses the arguments of a call, which may be positional expressions or
+// keyword arguments in the form `name=value`
+func (p *Parser) parseArguments() []ast.Node {
+	args := make([]ast.Node, 0)
+
+	cur := p.lexer.PeekToken()
+	for !cur.Is(tokens.Rparen) {
+		p.eatNewLines()
+
+		var arg ast.Node
+		cur = p.lexer.PeekToken()
+		next := p.lexer.PeekTokenN(1)
+		if cur.Is(tokens.Identifier) && next.Is(tokens.Assignment) && next.Literal == "=" {
+			p.lexer.EatToken()
+			p.lexer.EatToken()
+
+			value := p.checkPipe(p.parseSingleExpression(order.Lowest))
+			if value == nil {
+				p.RegisterError(fmt.Sprintf("expected expression for keyword argument '%s'", cur.Literal), next)
+				break
+			}
+
+			arg = &ast.KeywordArgument{
+				Token: cur,
+				Name:  cur.Literal,
+				Value: value,
+			}
+
+		} else {
+			arg = p.checkPipe(p.parseSingleExpression(order.Lowest))
+			if arg == nil {
+				break
+			}
+		}
+
+		args = append(args, arg)
+
+		cur = p.lexer.PeekToken()
+		if cur.Is(tokens.Comma) {
+			p.lexer.EatToken()
+		}
+	}
+
+	return args
+}
+
 func (p *Parser) parseSingleExpression(priority int) ast.Node {
 	cur := p.lexer.PeekToken()
 	// fmt.Println("parsing expression", priority, "-", cur)
 
 	prefixFn := p.prefixFns[cur.Type]
 
-	if prefixFn == nil {
+	// braces only start an expression as a destructuring pattern
+	if prefixFn == nil || cur.Is(tokens.Lbrace) && !p.isFieldPattern(tokens.Assignment, tokens.Comma, tokens.Rparen) {
 		return p.checkArrowDef(nil)
 	}
 
@@ -1060,12 +1585,15 @@ func (p *Parser) checkArrowDef(left ast.Node) ast.Node {
 
 	p.eatNewLines()
 
+	labels := p.labels
+	p.labels = nil
 	cur = p.lexer.PeekToken()
 	if cur.Is(tokens.Lbrace) {
 		node.Body = p.parseBlock()
 	} else {
 		node.Body = p.parseSingleExpression(order.Lowest)
 	}
+	p.labels = labels
 
 	return node
 }
@@ -1095,18 +1623,30 @@ func (p *Parser) convertLeftToParameters(left ast.Node) []ast.Node {
 				spread = true
 			}
 
-			t, ok := v.(*ast.Identifier)
-			if !ok {
+			switch t := v.(type) {
+			case *ast.Identifier:
+				values = append(values, &ast.Parameter{
+					Token:  t.Token,
+					Name:   t.Value,
+					Spread: hasSpread,
+				})
+
+			case *ast.Tuple, *ast.FieldPattern:
+				if _, err := p.assertAssignmentTargets(t); hasSpread || err != "" {
+					p.RegisterError(fmt.Sprintf("invalid parameter '%s'", v.GetToken().Literal), v.GetToken())
+					return nil
+				}
+
+				values = append(values, &ast.Parameter{
+					Token:   t.GetToken(),
+					Pattern: t,
+				})
+
+			default:
 				p.RegisterError(fmt.Sprintf("invalid parameter '%s'", v.GetToken().Literal), v.GetToken())
 				return nil
 			}
 
-			values = append(values, &ast.Parameter{
-				Token:  t.Token,
-				Name:   t.Value,
-				Spread: hasSpread,
-			})
-
 		}
 		return values
 	case nil:
@@ -1155,12 +1695,6 @@ func (p *Parser) parseInitializer() ast.Initializer {
 		}
 
 		if tp == 'M' {
-			name, ok := first.(*ast.Identifier)
-			if !ok {
-				p.RegisterError(fmt.Sprintf("invalid map initializer key '%s'", cur.Literal), cur)
-				return nil
-			}
-
 			if !p.Expect(tokens.Colon) {
 				return nil
 			}
@@ -1173,9 +1707,51 @@ func (p *Parser) parseInitializer() ast.Initializer {
 				p.RegisterError(fmt.Sprintf("invalid map initializer value '%s'", cur.Literal), cur)
 				return nil
 			}
-			initializer.(*ast.MapInitializer).Values[name.Value] = p.checkPipe(exp)
+
+			mapInit := initializer.(*ast.MapInitializer)
+			if p.isComprehension() {
+				if len(mapInit.Values) > 0 {
+					p.RegisterError("comprehensions cannot be mixed with other initializer values", cur)
+					return nil
+				}
+
+				mapInit.Comprehension = p.parseComprehension(first, exp)
+				if mapInit.Comprehension == nil {
+					return nil
+				}
+				p.eatNewLines()
+				break
+			}
+
+			name, ok := first.(*ast.Identifier)
+			if !ok {
+				p.RegisterError(fmt.Sprintf("invalid map initializer key '%s'", cur.Literal), cur)
+				return nil
+			}
+			mapInit.Values[name.Value] = p.checkPipe(exp)
 		} else {
-			initializer.(*ast.ListInitializer).Values = append(initializer.(*ast.ListInitializer).Values, first)
+			listInit := initializer.(*ast.ListInitializer)
+			if p.isComprehension() {
+				if len(listInit.Values) > 0 {
+					p.RegisterError("comprehensions cannot be mixed with other initializer values", cur)
+					return nil
+				}
+
+				comprehension := p.parseComprehension(nil, first)
+				if comprehension == nil {
+					return nil
+				}
+
+				// a list comprehension is spread into the initializer as a lazy iterator
+				listInit.Values = append(listInit.Values, &ast.SpreadOut{
+					Token:  comprehension.Token,
+					Target: comprehension,
+				})
+				p.eatNewLines()
+				break
+			}
+
+			listInit.Values = append(listInit.Values, first)
 		}
 
 		cur = p.lexer.PeekToken()
@@ -1194,6 +1770,122 @@ func (p *Parser) parseInitializer() ast.Initializer {
 	return initializer
 }
 
+// isComprehension checks if the next token, ignoring new lines, starts the
+// `for` clause of a comprehension. The new lines are eaten when it does.
+func (p *Parser) isComprehension() bool {
+	i := 0
+	cur := p.lexer.PeekTokenN(i)
+	for cur.Is(tokens.Newline) {
+		i++
+		cur = p.lexer.PeekTokenN(i)
+	}
+
+	if !cur.Is(tokens.Keyword) || cur.Literal != "for" {
+		return false
+	}
+
+	p.eatNewLines()
+	return true
+}
+
+// parseComprehension parses the `for <target> in <iterable>` and
+// `if <condition>` clauses following the value of a comprehension. The key is
+// only given for map comprehensions.
+func (p *Parser) parseComprehension(key, value ast.Node) *ast.Comprehension {
+	comprehension := &ast.Comprehension{
+		Token:   p.lexer.PeekToken(),
+		Key:     key,
+		Value:   value,
+		Clauses: []*ast.ComprehensionClause{},
+	}
+
+	for {
+		p.eatNewLines()
+		cur := p.lexer.PeekToken()
+		if !cur.Is(tokens.Keyword) || (cur.Literal != "for" && cur.Literal != "if") {
+			break
+		}
+		p.lexer.EatToken()
+
+		if cur.Literal == "if" {
+			condition := p.parseSingleExpression(order.Lowest)
+			if condition == nil {
+				p.RegisterError("invalid comprehension filter, missing condition", cur)
+				return nil
+			}
+
+			comprehension.Clauses = append(comprehension.Clauses, &ast.ComprehensionClause{
+				Condition: condition,
+			})
+			continue
+		}
+
+		target := p.parseComprehensionTarget()
+		if target == nil {
+			p.RegisterError("invalid comprehension, missing assignment target", cur)
+			return nil
+		}
+
+		_, err := p.assertAssignmentTargets(target)
+		if err != "" {
+			p.RegisterError("invalid comprehension assignment: "+err, cur)
+			return nil
+		}
+
+		in := p.lexer.PeekToken()
+		if !in.Is(tokens.Keyword) || in.Literal != "in" {
+			p.RegisterError(fmt.Sprintf("expecting 'in' token in comprehension, received '%s'.", in.Literal), in)
+			return nil
+		}
+		p.lexer.EatToken()
+
+		iterable := p.parseSingleExpression(order.Lowest)
+		if iterable == nil {
+			p.RegisterError("invalid comprehension, missing iterable", in)
+			return nil
+		}
+
+		comprehension.Clauses = append(comprehension.Clauses, &ast.ComprehensionClause{
+			Target:   target,
+			Iterable: p.checkPipe(iterable),
+		})
+	}
+
+	return comprehension
+}
+
+// parseComprehensionTarget parses the comma separated targets of a `for`
+// clause, stopping before the `in` keyword.
+func (p *Parser) parseComprehensionTarget() ast.Node {
+	targets := []ast.Node{}
+
+	cur := p.lexer.PeekToken()
+	for {
+		target := p.parseSingleExpression(order.In)
+		if target == nil {
+			break
+		}
+		targets = append(targets, target)
+
+		if !p.lexer.PeekToken().Is(tokens.Comma) {
+			break
+		}
+		p.lexer.EatToken()
+	}
+
+	switch len(targets) {
+	case 0:
+		return nil
+	case 1:
+		return targets[0]
+	default:
+		return &ast.Tuple{
+			Token:  cur,
+			Values: targets,
+		}
+	}
+}
+
 func (p *Parser) checkPipe(left ast.Node) ast.Node {
 	cur := p.lexer.PeekToken()
 	nxt := p.lexer.PeekTokenN(1)
@@ -1212,6 +1904,9 @@ func (p *Parser) checkPipe(left ast.Node) ast.Node {
 
 func (p *Parser) parsePipe(left ast.Node) ast.Node {
 	cur := p.lexer.PeekToken()
+	if cur.Literal == "|>" {
+		return p.parseForward(left)
+	}
 	p.lexer.EatToken()
 
 	pipe := &ast.Pipe{
@@ -1266,7 +1961,7 @@ func (p *Parser) parsePipe(left ast.Node) ast.Node {
 
 	if p.lexer.PeekToken().Is(tokens.Lparen) {
 		p.lexer.EatToken()
-		pipeFn.Arguments = p.parseExpressionList()
+		pipeFn.Arguments = p.parseArguments()
 
 		if !p.Expect(tokens.Rparen) {
 			return nil
@@ -1276,37 +1971,92 @@ func (p *Parser) parsePipe(left ast.Node) ast.Node {
 	pipe.PipeFn = pipeFn
 
 	cur = p.lexer.PeekToken()
-	if cur.Is(tokens.Spread) || cur.Is(tokens.Identifier) || cur.Is(tokens.Colon) {
-		argFn := &ast.FunctionDef{
-			Token:     cur,
-			Scoped:    false,
-			Generator: false,
-			Name:      "Piped Function",
-		}
+	nxt := p.lexer.PeekTokenN(1)
+	if cur.Is(tokens.Identifier) && (nxt.Is(tokens.Lparen) || nxt.Is(tokens.Dot)) {
+		// a function expression, such as the partial `clamp(_, 0, 10)`
+		pipe.ArgFn = p.parseSingleExpression(order.Pipe)
 
-		if !cur.Is(tokens.Colon) {
-			argFn.Params = p.parseParameters()
+	} else if cur.Is(tokens.Spread) || cur.Is(tokens.Identifier) || cur.Is(tokens.Colon) || p.isFieldPattern(tokens.Colon, tokens.Comma) {
+		if argFn := p.parseArgFn(); argFn != nil {
+			pipe.ArgFn = argFn
 		}
+	}
+
+	return pipe
+}
+
+// Parses the `x, y: expr` function given to pipe stages, returning nil if
+// there is no body
+func (p *Parser) parseArgFn() ast.Node {
+	cur := p.lexer.PeekToken()
+	argFn := &ast.FunctionDef{
+		Token:     cur,
+		Scoped:    false,
+		Generator: false,
+		Name:      "Piped Function",
+	}
+
+	if !cur.Is(tokens.Colon) {
+		argFn.Params = p.parseParameters()
+	}
 
+	cur = p.lexer.PeekToken()
+	if cur.Is(tokens.Colon) {
+		p.lexer.EatToken()
+
+		labels := p.labels
+		p.labels = nil
 		cur = p.lexer.PeekToken()
-		if cur.Is(tokens.Colon) {
-			p.lexer.EatToken()
+		if cur.Is(tokens.Lbrace) {
+			argFn.Body = p.parseBlock()
+		} else {
+			argFn.Body = p.parseExpressionTuple()
+		}
+		p.labels = labels
+	}
 
-			cur = p.lexer.PeekToken()
-			if cur.Is(tokens.Lbrace) {
-				argFn.Body = p.parseBlock()
-			} else {
-				argFn.Body = p.parseExpressionTuple()
-			}
+	if argFn.Body == nil {
+		return nil
+	}
+
+	return argFn
+}
+
+// Reports if the next tokens start an argument function, such as `x: expr`,
+// `x, y: expr` or `: expr`
+func (p *Parser) isArgFn() bool {
+	cur := p.lexer.PeekToken()
+	nxt := p.lexer.PeekTokenN(1)
+	return cur.Is(tokens.Spread) ||
+		cur.Is(tokens.Colon) ||
+		cur.Is(tokens.Identifier) && (nxt.Is(tokens.Colon) || nxt.Is(tokens.Comma)) ||
+		p.isFieldPattern(tokens.Colon, tokens.Comma)
+}
+
+func (p *Parser) parseForward(left ast.Node) ast.Node {
+	cur := p.lexer.EatToken()
+	forward := &ast.Forward{
+		Token: cur,
+		Left:  left,
+	}
+
+	if !p.isArgFn() {
+		forward.Target = p.parseSingleExpression(order.Pipe)
+		if forward.Target == nil {
+			p.RegisterError(fmt.Sprintf("invalid forward target '%s'", p.lexer.PeekToken().Literal), cur)
+			return nil
 		}
+	}
 
-		if argFn.Body != nil {
-			pipe.ArgFn = argFn
+	if p.isArgFn() {
+		forward.ArgFn = p.parseArgFn()
+		if forward.ArgFn == nil {
+			p.RegisterError(fmt.Sprintf("invalid forward function"), cur)
+			return nil
 		}
-		cur = p.lexer.PeekToken()
 	}
 
-	return pipe
+	return forward
 }
 
 func (p *Parser) parseMatch() ast.Node {
@@ -1393,9 +2143,28 @@ func (p *Parser) parsePrefixKeyword() ast.Node {
 	case "fn":
 		return p.parseFunctionDef()
 
+	case "yield":
+		return p.parseReturn()
+
+	case "for", "pipe":
+		return p.parseLoop()
+
+	case "try":
+		return p.parseTry()
+
 	case "data":
 		return p.parseDataDef()
 
+	case "protocol":
+		return p.parseProtocolDef()
+
+	case "enum":
+		return p.parseEnumDef()
+
+	case "super":
+		p.lexer.EatToken()
+		return &ast.Super{Token: cur}
+
 	case "match":
 		return p.parseMatch()
 
@@ -1405,6 +2174,63 @@ func (p *Parser) parsePrefixKeyword() ast.Node {
 	}
 }
 
+// Parses `try expr`, which returns the error of expr from the enclosing
+// function
+func (p *Parser) parseTry() ast.Node {
+	cur := p.lexer.PeekToken()
+	p.lexer.EatToken()
+
+	exp := p.parseSingleExpression(order.Unary)
+	if exp == nil {
+		return nil
+	}
+
+	return &ast.Try{
+		Token:      cur,
+		Expression: exp,
+	}
+}
+
+func (p *Parser) parsePrefixAt() ast.Node {
+	decorators := p.parseDecorators()
+	if decorators == nil {
+		return nil
+	}
+
+	cur := p.lexer.PeekToken()
+	if !cur.Is(tokens.Keyword) || cur.Literal != "fn" {
+		p.RegisterError(fmt.Sprintf("decorators must be followed by a function definition"), cur)
+		return nil
+	}
+
+	fn := p.parseFunctionDef()
+	if fn == nil {
+		return nil
+	}
+
+	fn.(*ast.FunctionDef).Decorators = decorators
+	return fn
+}
+
+// Parses a sequence of `@decorator` expressions, each one in its own line or
+// before the function definition
+func (p *Parser) parseDecorators() []ast.Node {
+	decorators := []ast.Node{}
+	for p.lexer.PeekToken().Is(tokens.At) {
+		at := p.lexer.EatToken()
+		decorator := p.parseSingleExpression(order.Lowest)
+		if decorator == nil {
+			p.RegisterError(fmt.Sprintf("invalid decorator"), at)
+			return nil
+		}
+
+		decorators = append(decorators, decorator)
+		p.eatNewLines()
+	}
+
+	return decorators
+}
+
 func (p *Parser) parsePrefixNumber() ast.Node {
 	cur := p.lexer.PeekToken()
 	v, e := strconv.ParseFloat(cur.Literal, 64)
@@ -1450,7 +2276,17 @@ func (p *Parser) parsePrefixOperator() ast.Node {
 func (p *Parser) parsePrefixParenthesis() ast.Node {
 	p.lexer.EatToken()
 	p.eatNewLines()
+
+	cur := p.lexer.PeekToken()
+	if cur.Is(tokens.Identifier) && p.lexer.PeekTokenN(1).Is(tokens.Colon) && !isLoopKeyword(p.lexer.PeekTokenN(2)) {
+		return p.parseNamedTuple()
+	}
+
+	// object creation is allowed inside parenthesis, even in conditions
+	inCondition := p.inCondition
+	p.inCondition = false
 	e := p.parseSingleExpression(order.Lowest)
+	p.inCondition = inCondition
 	p.eatNewLines()
 	p.Expect(tokens.Rparen, tokens.Comma)
 
@@ -1466,8 +2302,69 @@ func (p *Parser) parsePrefixParenthesis() ast.Node {
 	return e
 }
 
+// Parses the `name: value` fields of a named tuple, such as `(min: 1, max: 9)`,
+// after the opening parenthesis
+func (p *Parser) parseNamedTuple() ast.Node {
+	tuple := &ast.Tuple{
+		Token:  p.lexer.PeekToken(),
+		Names:  []string{},
+		Values: []ast.Node{},
+	}
+
+	inCondition := p.inCondition
+	p.inCondition = false
+
+	for {
+		p.eatNewLines()
+		cur := p.lexer.PeekToken()
+		if cur.Is(tokens.Rparen) {
+			break
+		}
+
+		if !cur.Is(tokens.Identifier) || !p.lexer.PeekTokenN(1).Is(tokens.Colon) {
+			p.RegisterError(fmt.Sprintf("named tuple fields must be given as 'name: value'"), cur)
+			return nil
+		}
+
+		if slices.Contains(tuple.Names, cur.Literal) {
+			p.RegisterError(fmt.Sprintf("duplicated field '%s' in named tuple", cur.Literal), cur)
+			return nil
+		}
+
+		p.lexer.EatToken()
+		p.lexer.EatToken()
+
+		value := p.parseSingleExpression(order.Lowest)
+		if value == nil {
+			p.RegisterError(fmt.Sprintf("expected expression for field '%s'", cur.Literal), cur)
+			return nil
+		}
+
+		tuple.Names = append(tuple.Names, cur.Literal)
+		tuple.Values = append(tuple.Values, value)
+
+		p.eatNewLines()
+		if !p.lexer.PeekToken().Is(tokens.Comma) {
+			break
+		}
+		p.lexer.EatToken()
+	}
+	p.inCondition = inCondition
+
+	if !p.Expect(tokens.Rparen) {
+		return nil
+	}
+	p.lexer.EatToken()
+
+	return tuple
+}
+
 func (p *Parser) parsePrefixIdentifier() ast.Node {
 	cur := p.lexer.PeekToken()
+	if p.lexer.PeekTokenN(1).Is(tokens.Colon) && isLoopKeyword(p.lexer.PeekTokenN(2)) {
+		return p.parseLabeledLoop()
+	}
+
 	// fmt.Println("parsePrefixIdentifier", cur)
 	p.lexer.EatToken()
 	return &ast.Identifier{
@@ -1526,7 +2423,7 @@ func (p *Parser) parseInfixCall(left ast.Node) ast.Node {
 
 	if p.lexer.PeekToken().Is(tokens.Lparen) {
 		p.lexer.EatToken()
-		node.Arguments = p.parseExpressionList()
+		node.Arguments = p.parseArguments()
 
 		if !p.Expect(tokens.Rparen) {
 			return nil
@@ -1548,11 +2445,32 @@ func (p *Parser) parseInfixCall(left ast.Node) ast.Node {
 }
 
 func (p *Parser) parseInfixBracket(left ast.Node) ast.Node {
+	ini := p.lexer.PeekToken()
 	p.lexer.EatToken()
 
 	node := &ast.Indexing{
+		Token:  ini,
 		Target: left,
-		Values: p.parseExpressionList(),
+		Values: []ast.Node{},
+	}
+
+	cur := p.lexer.PeekToken()
+	for !cur.Is(tokens.Rbracket) && !cur.Is(tokens.Eof) {
+		p.eatNewLines()
+
+		index := p.parseIndex()
+		if index == nil {
+			break
+		}
+
+		node.Values = append(node.Values, index)
+
+		cur = p.lexer.PeekToken()
+		if !cur.Is(tokens.Comma) {
+			break
+		}
+		p.lexer.EatToken()
+		cur = p.lexer.PeekToken()
 	}
 
 	p.Expect(tokens.Rbracket)
@@ -1560,6 +2478,43 @@ func (p *Parser) parseInfixBracket(left ast.Node) ast.Node {
 	return node
 }
 
+// Parses a single index, which may be an expression or a slice in the form
+// `start:stop:step`, where every part is optional
+func (p *Parser) parseIndex() ast.Node {
+	cur := p.lexer.PeekToken()
+
+	var start ast.Node
+	if !cur.Is(tokens.Colon) {
+		start = p.checkPipe(p.parseSingleExpression(order.Lowest))
+		if start == nil {
+			return nil
+		}
+	}
+
+	if !p.lexer.PeekToken().Is(tokens.Colon) {
+		return start
+	}
+
+	slice := &ast.Slice{
+		Token: cur,
+		Start: start,
+	}
+
+	p.lexer.EatToken()
+	if !isEndOfIndex(p.lexer.PeekToken()) {
+		slice.Stop = p.parseSingleExpression(order.Lowest)
+	}
+
+	if p.lexer.PeekToken().Is(tokens.Colon) {
+		p.lexer.EatToken()
+		if !isEndOfIndex(p.lexer.PeekToken()) {
+			slice.Step = p.parseSingleExpression(order.Lowest)
+		}
+	}
+
+	return slice
+}
+
 func (p *Parser) parseInfixDot(left ast.Node) ast.Node {
 	p.lexer.EatToken()
 
@@ -1619,6 +2574,10 @@ func isEndOfBlock(t *tokens.Token) bool {
 	return t.Is(tokens.Rbrace) || t.Is(tokens.Eof)
 }
 
+func isLoopKeyword(t *tokens.Token) bool {
+	return t.Is(tokens.Keyword) && (t.Literal == "for" || t.Literal == "pipe")
+}
+
 func isEndOfStatement(t *tokens.Token) bool {
 	return t.Is(tokens.Semicolon) || t.Is(tokens.Eof) || t.Is(tokens.Newline) || t.Is(tokens.Rbrace)
 }
@@ -1627,6 +2586,10 @@ func isEndOfExpression(t *tokens.Token) bool {
 	return t.Is(tokens.Semicolon) // t.Is(token.Newline) ||
 }
 
+func isEndOfIndex(t *tokens.Token) bool {
+	return t.Is(tokens.Colon) || t.Is(tokens.Comma) || t.Is(tokens.Rbracket)
+}
+
 func isUnary(t *tokens.Token) bool {
 	switch t.Literal {
 	case "+", "-", "!":
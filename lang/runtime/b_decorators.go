@@ -0,0 +1,150 @@
+package runtime
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Wraps the function into a native one with the same name, forwarding the
+// keyword arguments untouched
+func decorate(target *Instance, impl MetaFunction) *Instance {
+	wrapper := Function.CreateNative(nameOf(target), []*FunctionParam{}, impl)
+	wrapper.AsFunction().RawKeywords = true
+	return wrapper
+}
+
+func nameOf(target *Instance) string {
+	if target.IsFunction() {
+		return target.AsFunction().Name
+	}
+
+	return target.Type.GetName()
+}
+
+// Returns the arguments as a tuple of the positional values and of the
+// (name, value) pairs of the keyword ones, sorted by name, so calls giving
+// the same arguments share a key
+func memoKey(r *Runtime, s *Scope, args []*Instance) *Instance {
+	positional, keywords, err := splitKeywordArguments(r, s, args)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(keywords, func(i, j int) bool { return keywords[i].Name < keywords[j].Name })
+	pairs := []*Instance{}
+	for _, keyword := range keywords {
+		pairs = append(pairs, Tuple.Create(String.Create(keyword.Name), keyword.Value))
+	}
+
+	return Tuple.Create(Tuple.Create(positional...), Tuple.Create(pairs...))
+}
+
+var b_memoize = fn("memoize", p("fn")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if len(args) == 0 || !args[0].IsCallable() {
+			return throw(r, s, "memoize requires a function")
+		}
+
+		target := args[0]
+		keys := map[string]*Instance{}
+		cache := map[string]*Instance{}
+		return decorate(target, func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			tuple := memoKey(r, s, args)
+			if s.IsInterruptedAs(FlowRaise) {
+				return tuple
+			}
+
+			key, has := hashedKey(r, s, tuple, keys)
+			if s.IsInterruptedAs(FlowRaise) {
+				return Boolean.FALSE
+			}
+
+			if has {
+				return cache[key]
+			}
+
+			value := r.callValue(target, nil, args, s)
+			if s.IsInterruptedAs(FlowRaise) {
+				return value
+			}
+
+			keys[key] = keySnapshot(tuple, nil)
+			cache[key] = value
+			return value
+		})
+	})
+
+var b_trace = fn("trace", p("fn")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if len(args) == 0 || !args[0].IsCallable() {
+			return throw(r, s, "trace requires a function")
+		}
+
+		target := args[0]
+		name := nameOf(target)
+		return decorate(target, func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			values := []string{}
+			for _, arg := range args {
+				values = append(values, arg.Repr())
+			}
+
+			call := fmt.Sprintf("%s(%s)", name, strings.Join(values, ", "))
+			indent := strings.Repeat("  ", r.traceDepth)
+			fmt.Fprintln(r.TraceOutput, indent+call)
+
+			r.traceDepth++
+			value := r.callValue(target, nil, args, s)
+			r.traceDepth--
+
+			if s.IsInterruptedAs(FlowRaise) {
+				fmt.Fprintln(r.TraceOutput, indent+call+" raised "+s.Interruption.Value.Repr())
+				return value
+			}
+
+			fmt.Fprintln(r.TraceOutput, indent+call+" -> "+value.Repr())
+			return value
+		})
+	})
+
+var b_deprecated = fn("deprecated", p("fn")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if len(args) == 0 {
+			return throw(r, s, "deprecated requires a function or a message")
+		}
+
+		// `@deprecated('message')` returns the decorator itself
+		if args[0].IsString() {
+			message := AsString(args[0])
+			return Function.CreateNative("deprecated", []*FunctionParam{{Name: "fn"}}, func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+				if len(args) == 0 || !args[0].IsCallable() {
+					return throw(r, s, "deprecated requires a function")
+				}
+				return deprecate(args[0], message)
+			})
+		}
+
+		if !args[0].IsCallable() {
+			return throw(r, s, "deprecated requires a function or a message")
+		}
+
+		return deprecate(args[0], "")
+	})
+
+// Wraps the function so its first call warns about the deprecation
+func deprecate(target *Instance, message string) *Instance {
+	warning := fmt.Sprintf("warning: '%s' is deprecated", nameOf(target))
+	if message != "" {
+		warning += ": " + message
+	}
+
+	warned := false
+	return decorate(target, func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if !warned {
+			warned = true
+			fmt.Fprintln(r.WarningOutput, warning)
+		}
+
+		return r.callValue(target, nil, args, s)
+	})
+}
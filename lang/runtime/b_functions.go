@@ -58,7 +58,7 @@ var b_len = fn("len", p("obj")).
 
 var b_iter = fn("iter", p("obj")).
 	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
-		obj, err := arg(args, 0).Validate()
+		obj, err := arg(args, 0).Implements(Iterable).Validate()
 		if err != nil {
 			return throw(r, s, err.Error())
 		}
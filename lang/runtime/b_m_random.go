@@ -0,0 +1,237 @@
+package runtime
+
+import (
+	"time"
+)
+
+var b_m_random = createRandomModule()
+
+func createRandomModule() *Instance {
+	module := Module.Create("random")
+
+	Module.Add(module, "seed", fn("seed", p("seed", Boolean.FALSE)).
+		as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			i_seed, err := arg(args, 0).Optional(Boolean.FALSE).IsNumber().Validate()
+			if err != nil {
+				return throw(r, s, err.Error())
+			}
+
+			seed := time.Now().UnixNano()
+			if i_seed != Boolean.FALSE {
+				seed = int64(AsNumber(i_seed))
+			}
+
+			r.random.Seed(seed)
+			return Number.Create(float64(seed))
+		}),
+	)
+
+	Module.Add(module, "number", fn("number").
+		as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			return Number.Create(r.random.Float64())
+		}),
+	)
+
+	Module.Add(module, "uniform", fn("uniform", p("min"), p("max")).
+		as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			i_min, err := arg(args, 0).IsNumber().Validate()
+			if err != nil {
+				return throw(r, s, err.Error())
+			}
+
+			i_max, err := arg(args, 1).IsNumber().Validate()
+			if err != nil {
+				return throw(r, s, err.Error())
+			}
+
+			min := AsNumber(i_min)
+			max := AsNumber(i_max)
+			return Number.Create(min + r.random.Float64()*(max-min))
+		}),
+	)
+
+	Module.Add(module, "int", fn("int", p("min"), p("max")).
+		as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			i_min, err := arg(args, 0).IsNumber().Validate()
+			if err != nil {
+				return throw(r, s, err.Error())
+			}
+
+			i_max, err := arg(args, 1).IsNumber().Validate()
+			if err != nil {
+				return throw(r, s, err.Error())
+			}
+
+			min := AsInteger(i_min)
+			max := AsInteger(i_max)
+			if max < min {
+				return throw(r, s, "random int requires min '%d' to be lower or equal than max '%d'", min, max)
+			}
+
+			return Number.Create(float64(min + r.random.Intn(max-min+1)))
+		}),
+	)
+
+	Module.Add(module, "choice", fn("choice", p("items")).
+		as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			i_items, err := arg(args, 0).Validate()
+			if err != nil {
+				return throw(r, s, err.Error())
+			}
+
+			values, e := collect(r, s, i_items)
+			if e != nil {
+				return e
+			}
+
+			if len(values) == 0 {
+				return throw(r, s, "cannot choose from an empty sequence")
+			}
+
+			return values[r.random.Intn(len(values))]
+		}),
+	)
+
+	Module.Add(module, "shuffle", fn("shuffle", p("list")).
+		as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			i_list, err := arg(args, 0).IsList().Validate()
+			if err != nil {
+				return throw(r, s, err.Error())
+			}
+
+			if args[0].Frozen {
+				return r.Throw(Error.FrozenValue(s, args[0]), s)
+			}
+
+			values := i_list.AsList().Values
+			r.random.Shuffle(len(values), func(i, j int) {
+				values[i], values[j] = values[j], values[i]
+			})
+
+			return i_list
+		}),
+	)
+
+	Module.Add(module, "sample", fn("sample", p("items"), p("amount")).
+		as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			i_items, err := arg(args, 0).Validate()
+			if err != nil {
+				return throw(r, s, err.Error())
+			}
+
+			i_amount, err := arg(args, 1).IsNumber().Validate()
+			if err != nil {
+				return throw(r, s, err.Error())
+			}
+
+			values, e := collect(r, s, i_items)
+			if e != nil {
+				return e
+			}
+
+			amount := AsInteger(i_amount)
+			if amount < 0 || amount > len(values) {
+				return throw(r, s, "sample amount '%d' must be between 0 and %d", amount, len(values))
+			}
+
+			result := make([]*Instance, 0, amount)
+			for _, idx := range r.random.Perm(len(values))[:amount] {
+				result = append(result, values[idx])
+			}
+
+			return List.Create(result...)
+		}),
+	)
+
+	Module.Add(module, "weighted", fn("weighted", p("items"), p("weights")).
+		as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			i_items, err := arg(args, 0).Validate()
+			if err != nil {
+				return throw(r, s, err.Error())
+			}
+
+			i_weights, err := arg(args, 1).Validate()
+			if err != nil {
+				return throw(r, s, err.Error())
+			}
+
+			values, e := collect(r, s, i_items)
+			if e != nil {
+				return e
+			}
+
+			weights, e := collect(r, s, i_weights)
+			if e != nil {
+				return e
+			}
+
+			if len(values) != len(weights) {
+				return throw(r, s, "weighted requires the same number of items and weights, got %d and %d", len(values), len(weights))
+			}
+
+			total := 0.0
+			for _, w := range weights {
+				if !w.IsNumber() || AsNumber(w) < 0 {
+					return throw(r, s, "weights must be non-negative numbers, got '%s'", w.Repr())
+				}
+				total += AsNumber(w)
+			}
+
+			if total <= 0 {
+				return throw(r, s, "weighted requires at least one positive weight")
+			}
+
+			target := r.random.Float64() * total
+			for i, w := range weights {
+				target -= AsNumber(w)
+				if target < 0 {
+					return values[i]
+				}
+			}
+
+			return values[len(values)-1]
+		}),
+	)
+
+	Module.Add(module, "normal", fn("normal", p("mean", Number.ZERO), p("stddev", Number.ONE)).
+		as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			i_mean, err := arg(args, 0).Optional(Number.ZERO).IsNumber().Validate()
+			if err != nil {
+				return throw(r, s, err.Error())
+			}
+
+			i_stddev, err := arg(args, 1).Optional(Number.ONE).IsNumber().Validate()
+			if err != nil {
+				return throw(r, s, err.Error())
+			}
+
+			return Number.Create(r.random.NormFloat64()*AsNumber(i_stddev) + AsNumber(i_mean))
+		}),
+	)
+
+	Module.Add(module, "exponential", fn("exponential", p("rate", Number.ONE)).
+		as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			i_rate, err := arg(args, 0).Optional(Number.ONE).IsNumber().Validate()
+			if err != nil {
+				return throw(r, s, err.Error())
+			}
+
+			rate := AsNumber(i_rate)
+			if rate <= 0 {
+				return throw(r, s, "exponential rate must be positive")
+			}
+
+			return Number.Create(r.random.ExpFloat64() / rate)
+		}),
+	)
+
+	Module.Add(module, "stream", fn("stream").
+		as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+				return Iteration.Create(Number.Create(r.random.Float64()))
+			})
+		}),
+	)
+
+	return module
+}
@@ -1,9 +1,11 @@
 package runtime
 
+import "sort"
+
 var b_map = Function.CreateNative("map",
 	[]*FunctionParam{
-		{"iter", nil, false},
-		{"func", nil, false},
+		{"iter", nil, false, nil},
+		{"func", nil, false, nil},
 	},
 	func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 		// self => function
@@ -46,8 +48,8 @@ var b_map = Function.CreateNative("map",
 
 var b_each = Function.CreateNative("each",
 	[]*FunctionParam{
-		{"iter", nil, false},
-		{"func", nil, false},
+		{"iter", nil, false, nil},
+		{"func", nil, false, nil},
 	},
 	func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 		// self => function
@@ -90,8 +92,8 @@ var b_each = Function.CreateNative("each",
 
 var b_filter = Function.CreateNative("filter",
 	[]*FunctionParam{
-		{"iter", nil, false},
-		{"func", nil, false},
+		{"iter", nil, false, nil},
+		{"func", nil, false, nil},
 	},
 	func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 		// self => function
@@ -139,9 +141,9 @@ var b_filter = Function.CreateNative("filter",
 
 var b_reduce = Function.CreateNative("reduce",
 	[]*FunctionParam{
-		{"iter", nil, false},
-		{"func", nil, false},
-		{"default", nil, false},
+		{"iter", nil, false, nil},
+		{"func", nil, false, nil},
+		{"default", nil, false, nil},
 	},
 	func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 		// self => function
@@ -243,8 +245,8 @@ var b_sum = fn("sum", p("iter"), p("first", GetFirstFn)).
 
 var b_takeWhile = Function.CreateNative("takeWhile",
 	[]*FunctionParam{
-		{"iter", nil, false},
-		{"func", nil, false},
+		{"iter", nil, false, nil},
+		{"func", nil, false, nil},
 	},
 	func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 		// self => function
@@ -294,9 +296,9 @@ var b_takeWhile = Function.CreateNative("takeWhile",
 
 var b_take = Function.CreateNative("take",
 	[]*FunctionParam{
-		{"iter", nil, false},
-		{"func", nil, false},
-		{"amount", nil, false},
+		{"iter", nil, false, nil},
+		{"func", nil, false, nil},
+		{"amount", nil, false, nil},
 	},
 	func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 		// self => function
@@ -454,7 +456,7 @@ var b_max = fn("max", p("iter"), p("func", GetFirstFn)).
 
 var b_first = Function.CreateNative("first",
 	[]*FunctionParam{
-		{"iter", nil, false},
+		{"iter", nil, false, nil},
 	},
 	func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 		// self => function
@@ -502,7 +504,7 @@ var b_first = Function.CreateNative("first",
 
 var b_last = Function.CreateNative("last",
 	[]*FunctionParam{
-		{"iter", nil, false},
+		{"iter", nil, false, nil},
 	},
 	func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 		// self => function
@@ -670,3 +672,1070 @@ var b_multiply = fn("multiply", p("next")).
 		)
 	},
 	)
+
+var b_zip = fn("zip", p("iter"), p("func"), p("others", nil, true)).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		i_iter, err := arg(args, 0).IsIterator().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		if len(args) > 1 && args[1] != Boolean.FALSE {
+			return throw(r, s, "zip does not accept a function")
+		}
+
+		iters := []*Instance{i_iter}
+		for _, other := range args[2:] {
+			iter, e := iterOf(r, s, other)
+			if e != nil {
+				return e
+			}
+			iters = append(iters, iter)
+		}
+
+		return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			values := []*Instance{}
+			for _, iter := range iters {
+				ret := advance(r, s, iter)
+				if s.IsInterruptedAs(FlowRaise) {
+					return ret
+				}
+
+				iteration := ret.AsIteration()
+				if AsBool(iteration.error()) {
+					return ret
+
+				} else if AsBool(iteration.done()) {
+					return Iteration.DONE
+				}
+
+				values = append(values, itemOf(iteration))
+			}
+
+			return Iteration.Create(Tuple.Create(values...))
+		})
+	})
+
+var b_enumerate = fn("enumerate", p("iter"), p("func"), p("start", Number.ZERO)).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if e := checkArgCount(r, s, self, args); e != nil {
+			return e
+		}
+
+		i_iter, err := arg(args, 0).IsIterator().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		if len(args) > 1 && args[1] != Boolean.FALSE {
+			return throw(r, s, "enumerate does not accept a function")
+		}
+
+		i_start, err := arg(args, 2).Optional(Number.ZERO).IsNumber().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		cur := AsNumber(i_start)
+		return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			ret := advance(r, s, i_iter)
+			if s.IsInterruptedAs(FlowRaise) {
+				return ret
+			}
+
+			iteration := ret.AsIteration()
+			if AsBool(iteration.error()) {
+				return ret
+
+			} else if AsBool(iteration.done()) {
+				return Iteration.DONE
+			}
+
+			idx := Number.Create(cur)
+			cur++
+			return Iteration.Create(Tuple.CreateNamed([]string{"index", "value"}, idx, itemOf(iteration)))
+		})
+	})
+
+var b_chain = fn("chain", p("iter"), p("func"), p("others", nil, true)).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		i_iter, err := arg(args, 0).IsIterator().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		if len(args) > 1 && args[1] != Boolean.FALSE {
+			return throw(r, s, "chain does not accept a function")
+		}
+
+		iters := []*Instance{i_iter}
+		for _, other := range args[2:] {
+			iter, e := iterOf(r, s, other)
+			if e != nil {
+				return e
+			}
+			iters = append(iters, iter)
+		}
+
+		cur := 0
+		return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			for cur < len(iters) {
+				ret := advance(r, s, iters[cur])
+				if s.IsInterruptedAs(FlowRaise) {
+					return ret
+				}
+
+				iteration := ret.AsIteration()
+				if AsBool(iteration.error()) {
+					return ret
+
+				} else if AsBool(iteration.done()) {
+					cur++
+
+				} else {
+					return ret
+				}
+			}
+
+			return Iteration.DONE
+		})
+	})
+
+var b_flatMap = fn("flatMap", p("iter"), p("func", GetFirstFn)).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if e := checkArgCount(r, s, self, args); e != nil {
+			return e
+		}
+
+		i_iter, err := arg(args, 0).IsIterator().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		i_fn, err := arg(args, 1).Optional(GetFirstFn).IsFunction().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		var inner *Instance
+		return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			for {
+				if inner != nil {
+					ret := advance(r, s, inner)
+					if s.IsInterruptedAs(FlowRaise) {
+						return ret
+					}
+
+					iteration := ret.AsIteration()
+					if AsBool(iteration.error()) {
+						return ret
+
+					} else if !AsBool(iteration.done()) {
+						return ret
+					}
+
+					inner = nil
+				}
+
+				ret := advance(r, s, i_iter)
+				if s.IsInterruptedAs(FlowRaise) {
+					return ret
+				}
+
+				iteration := ret.AsIteration()
+				if AsBool(iteration.error()) {
+					return ret
+
+				} else if AsBool(iteration.done()) {
+					return Iteration.DONE
+				}
+
+				val := i_fn.OnCall(r, s, iteration.value().AsTuple().Values...)
+				if s.IsInterruptedAs(FlowRaise) {
+					return val
+				}
+
+				iter, e := iterOf(r, s, val)
+				if e != nil {
+					return e
+				}
+				inner = iter
+			}
+		})
+	})
+
+var b_skip = fn("skip", p("iter"), p("func"), p("amount")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if e := checkArgCount(r, s, self, args); e != nil {
+			return e
+		}
+
+		i_iter, err := arg(args, 0).IsIterator().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		if len(args) > 1 && args[1] != Boolean.FALSE {
+			return throw(r, s, "skip does not accept a function")
+		}
+
+		i_amount, err := arg(args, 2).IsNumber().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		amount := AsInteger(i_amount)
+		skipped := 0
+		return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			for {
+				ret := advance(r, s, i_iter)
+				if s.IsInterruptedAs(FlowRaise) {
+					return ret
+				}
+
+				iteration := ret.AsIteration()
+				if AsBool(iteration.error()) {
+					return ret
+
+				} else if AsBool(iteration.done()) {
+					return Iteration.DONE
+
+				} else if skipped < amount {
+					skipped++
+
+				} else {
+					return ret
+				}
+			}
+		})
+	})
+
+var b_skipWhile = fn("skipWhile", p("iter"), p("func")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if e := checkArgCount(r, s, self, args); e != nil {
+			return e
+		}
+
+		i_iter, err := arg(args, 0).IsIterator().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		i_fn, err := arg(args, 1).IsFunction().Validate()
+		if err != nil {
+			return throw(r, s, "skipWhile requires a function")
+		}
+
+		skipping := true
+		return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			for {
+				ret := advance(r, s, i_iter)
+				if s.IsInterruptedAs(FlowRaise) {
+					return ret
+				}
+
+				iteration := ret.AsIteration()
+				if AsBool(iteration.error()) {
+					return ret
+
+				} else if AsBool(iteration.done()) {
+					return Iteration.DONE
+
+				} else if !skipping {
+					return ret
+				}
+
+				val := i_fn.OnCall(r, s, iteration.value().AsTuple().Values...)
+				if s.IsInterruptedAs(FlowRaise) {
+					return val
+				}
+
+				if !AsBool(val) {
+					skipping = false
+					return ret
+				}
+			}
+		})
+	})
+
+var b_chunk = fn("chunk", p("iter"), p("func"), p("size")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if e := checkArgCount(r, s, self, args); e != nil {
+			return e
+		}
+
+		i_iter, err := arg(args, 0).IsIterator().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		if len(args) > 1 && args[1] != Boolean.FALSE {
+			return throw(r, s, "chunk does not accept a function")
+		}
+
+		i_size, err := arg(args, 2).IsNumber().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		size := AsInteger(i_size)
+		if size < 1 {
+			return throw(r, s, "chunk size must be greater than 0")
+		}
+
+		finished := false
+		return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			if finished {
+				return Iteration.DONE
+			}
+
+			values := []*Instance{}
+			for len(values) < size {
+				ret := advance(r, s, i_iter)
+				if s.IsInterruptedAs(FlowRaise) {
+					return ret
+				}
+
+				iteration := ret.AsIteration()
+				if AsBool(iteration.error()) {
+					return ret
+
+				} else if AsBool(iteration.done()) {
+					finished = true
+					break
+				}
+
+				values = append(values, itemOf(iteration))
+			}
+
+			if len(values) == 0 {
+				return Iteration.DONE
+			}
+
+			return Iteration.Create(List.Create(values...))
+		})
+	})
+
+var b_distinct = fn("distinct", p("iter"), p("func", GetFirstFn)).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if e := checkArgCount(r, s, self, args); e != nil {
+			return e
+		}
+
+		i_iter, err := arg(args, 0).IsIterator().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		i_fn, err := arg(args, 1).Optional(GetFirstFn).IsFunction().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		seen := map[string]*Instance{}
+		return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			for {
+				ret := advance(r, s, i_iter)
+				if s.IsInterruptedAs(FlowRaise) {
+					return ret
+				}
+
+				iteration := ret.AsIteration()
+				if AsBool(iteration.error()) {
+					return ret
+
+				} else if AsBool(iteration.done()) {
+					return Iteration.DONE
+				}
+
+				key := i_fn.OnCall(r, s, iteration.value().AsTuple().Values...)
+				if s.IsInterruptedAs(FlowRaise) {
+					return key
+				}
+
+				k, has := hashedKey(r, s, key, seen)
+				if s.IsInterruptedAs(FlowRaise) {
+					return Boolean.FALSE
+				}
+
+				if !has {
+					seen[k] = keySnapshot(key, nil)
+					return ret
+				}
+			}
+		})
+	})
+
+var b_groupBy = fn("groupBy", p("iter"), p("func")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if e := checkArgCount(r, s, self, args); e != nil {
+			return e
+		}
+
+		i_iter, err := arg(args, 0).IsIterator().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		i_fn, err := arg(args, 1).IsFunction().Validate()
+		if err != nil {
+			return throw(r, s, "groupBy requires a function")
+		}
+
+		var groups []*Instance
+		cur := 0
+		return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			if groups == nil {
+				groups = []*Instance{}
+				keys := map[string]*Instance{}
+				index := map[string]*Instance{}
+
+				for {
+					ret := advance(r, s, i_iter)
+					if s.IsInterruptedAs(FlowRaise) {
+						return ret
+					}
+
+					iteration := ret.AsIteration()
+					if AsBool(iteration.error()) {
+						return ret
+
+					} else if AsBool(iteration.done()) {
+						break
+					}
+
+					key := i_fn.OnCall(r, s, iteration.value().AsTuple().Values...)
+					if s.IsInterruptedAs(FlowRaise) {
+						return key
+					}
+
+					k, has := hashedKey(r, s, key, keys)
+					if s.IsInterruptedAs(FlowRaise) {
+						return Boolean.FALSE
+					}
+
+					group := index[k]
+					if !has {
+						group = List.Create()
+						keys[k] = keySnapshot(key, nil)
+						index[k] = group
+						groups = append(groups, Tuple.CreateNamed([]string{"key", "items"}, key, group))
+					}
+
+					list := group.AsList()
+					list.Values = append(list.Values, itemOf(iteration))
+				}
+			}
+
+			if cur >= len(groups) {
+				return Iteration.DONE
+			}
+
+			cur++
+			return Iteration.Create(groups[cur-1])
+		})
+	})
+
+var b_partition = fn("partition", p("iter"), p("func")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if e := checkArgCount(r, s, self, args); e != nil {
+			return e
+		}
+
+		i_iter, err := arg(args, 0).IsIterator().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		i_fn, err := arg(args, 1).IsFunction().Validate()
+		if err != nil {
+			return throw(r, s, "partition requires a function")
+		}
+
+		var parts []*Instance
+		cur := 0
+		return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			if parts == nil {
+				matches := []*Instance{}
+				others := []*Instance{}
+
+				for {
+					ret := advance(r, s, i_iter)
+					if s.IsInterruptedAs(FlowRaise) {
+						return ret
+					}
+
+					iteration := ret.AsIteration()
+					if AsBool(iteration.error()) {
+						return ret
+
+					} else if AsBool(iteration.done()) {
+						break
+					}
+
+					val := i_fn.OnCall(r, s, iteration.value().AsTuple().Values...)
+					if s.IsInterruptedAs(FlowRaise) {
+						return val
+					}
+
+					if AsBool(val) {
+						matches = append(matches, itemOf(iteration))
+					} else {
+						others = append(others, itemOf(iteration))
+					}
+				}
+
+				parts = []*Instance{List.Create(matches...), List.Create(others...)}
+			}
+
+			if cur >= len(parts) {
+				return Iteration.DONE
+			}
+
+			cur++
+			return Iteration.Create(parts[cur-1])
+		})
+	})
+
+var b_scan = fn("scan", p("iter"), p("func"), p("initial", Number.ZERO)).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if e := checkArgCount(r, s, self, args); e != nil {
+			return e
+		}
+
+		i_iter, err := arg(args, 0).IsIterator().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		i_fn, err := arg(args, 1).IsFunction().Validate()
+		if err != nil {
+			return throw(r, s, "scan requires a function")
+		}
+
+		acc, err := arg(args, 2).Optional(Number.ZERO).Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			ret := advance(r, s, i_iter)
+			if s.IsInterruptedAs(FlowRaise) {
+				return ret
+			}
+
+			iteration := ret.AsIteration()
+			if AsBool(iteration.error()) {
+				return ret
+
+			} else if AsBool(iteration.done()) {
+				return Iteration.DONE
+			}
+
+			acc = i_fn.OnCall(r, s, append([]*Instance{acc}, iteration.value().AsTuple().Values...)...)
+			if s.IsInterruptedAs(FlowRaise) {
+				return acc
+			}
+
+			return Iteration.Create(acc)
+		})
+	})
+
+var b_oks = fn("oks", p("iter")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		return maybeFilter(r, s, false, args...)
+	})
+
+var b_errors = fn("errors", p("iter")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		return maybeFilter(r, s, true, args...)
+	})
+
+var b_count = fn("count", p("iter"), p("func", Boolean.FALSE)).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if e := checkArgCount(r, s, self, args); e != nil {
+			return e
+		}
+
+		i_iter, err := arg(args, 0).IsIterator().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		i_fn, err := arg(args, 1).Optional(Boolean.FALSE).IsFunction().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		finished := false
+		return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			if finished {
+				return Iteration.DONE
+			}
+
+			total := 0
+			for {
+				ret := advance(r, s, i_iter)
+				if s.IsInterruptedAs(FlowRaise) {
+					return ret
+				}
+
+				iteration := ret.AsIteration()
+				if AsBool(iteration.error()) {
+					return ret
+
+				} else if AsBool(iteration.done()) {
+					finished = true
+					return Iteration.Create(Number.Create(float64(total)))
+				}
+
+				if i_fn == Boolean.FALSE {
+					total++
+					continue
+				}
+
+				val := i_fn.OnCall(r, s, iteration.value().AsTuple().Values...)
+				if s.IsInterruptedAs(FlowRaise) {
+					return val
+				}
+
+				if AsBool(val) {
+					total++
+				}
+			}
+		})
+	})
+
+var b_any = fn("any", p("iter"), p("func", GetFirstFn)).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if e := checkArgCount(r, s, self, args); e != nil {
+			return e
+		}
+
+		return quantifier(r, s, true, args...)
+	})
+
+var b_all = fn("all", p("iter"), p("func", GetFirstFn)).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if e := checkArgCount(r, s, self, args); e != nil {
+			return e
+		}
+
+		return quantifier(r, s, false, args...)
+	})
+
+var b_sortBy = fn("sortBy", p("iter"), p("func", GetFirstFn), p("reverse", Boolean.FALSE)).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if e := checkArgCount(r, s, self, args); e != nil {
+			return e
+		}
+
+		i_iter, err := arg(args, 0).IsIterator().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		i_fn, err := arg(args, 1).Optional(GetFirstFn).IsFunction().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		i_reverse, err := arg(args, 2).Optional(Boolean.FALSE).IsBoolean().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		var values []*Instance
+		cur := 0
+		return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			if values == nil {
+				items, e := drain(r, s, i_iter)
+				if e != nil {
+					return e
+				}
+
+				keys := make([]*Instance, len(items))
+				for idx, item := range items {
+					keys[idx] = i_fn.OnCall(r, s, item.AsTuple().Values...)
+					if s.IsInterruptedAs(FlowRaise) {
+						return keys[idx]
+					}
+				}
+
+				e = sortInstances(r, s, items, keys, AsBool(i_reverse))
+				if e != nil {
+					return e
+				}
+				values = items
+			}
+
+			if cur >= len(values) {
+				return Iteration.DONE
+			}
+
+			cur++
+			return Iteration.CreateAsTuple(values[cur-1])
+		})
+	})
+
+var b_reverse = fn("reverse", p("iter"), p("func")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if e := checkArgCount(r, s, self, args); e != nil {
+			return e
+		}
+
+		i_iter, err := arg(args, 0).IsIterator().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		if len(args) > 1 && args[1] != Boolean.FALSE {
+			return throw(r, s, "reverse does not accept a function")
+		}
+
+		var values []*Instance
+		cur := 0
+		return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			if values == nil {
+				items, e := drain(r, s, i_iter)
+				if e != nil {
+					return e
+				}
+				values = items
+				cur = len(values)
+			}
+
+			if cur <= 0 {
+				return Iteration.DONE
+			}
+
+			cur--
+			return Iteration.CreateAsTuple(values[cur])
+		})
+	})
+
+var b_cycle = fn("cycle", p("iter"), p("func"), p("times", Boolean.FALSE)).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if e := checkArgCount(r, s, self, args); e != nil {
+			return e
+		}
+
+		i_iter, err := arg(args, 0).IsIterator().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		if len(args) > 1 && args[1] != Boolean.FALSE {
+			return throw(r, s, "cycle does not accept a function")
+		}
+
+		i_times, err := arg(args, 2).Optional(Boolean.FALSE).IsNumber().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		times := -1
+		if i_times != Boolean.FALSE {
+			times = AsInteger(i_times)
+		}
+
+		values := []*Instance{}
+		exhausted := false
+		round := 0
+		cur := 0
+		return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			if times >= 0 && round >= times {
+				return Iteration.DONE
+			}
+
+			if !exhausted {
+				ret := advance(r, s, i_iter)
+				if s.IsInterruptedAs(FlowRaise) {
+					return ret
+				}
+
+				iteration := ret.AsIteration()
+				if AsBool(iteration.error()) {
+					return ret
+
+				} else if !AsBool(iteration.done()) {
+					values = append(values, iteration.value())
+					return ret
+				}
+
+				exhausted = true
+				round++
+			}
+
+			if len(values) == 0 || times >= 0 && round >= times {
+				return Iteration.DONE
+			}
+
+			value := values[cur]
+			cur++
+			if cur >= len(values) {
+				cur = 0
+				round++
+			}
+
+			return Iteration.CreateAsTuple(value)
+		})
+	})
+
+var b_interleave = fn("interleave", p("iter"), p("func"), p("others", nil, true)).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		i_iter, err := arg(args, 0).IsIterator().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		if len(args) > 1 && args[1] != Boolean.FALSE {
+			return throw(r, s, "interleave does not accept a function")
+		}
+
+		iters := []*Instance{i_iter}
+		for _, other := range args[2:] {
+			iter, e := iterOf(r, s, other)
+			if e != nil {
+				return e
+			}
+			iters = append(iters, iter)
+		}
+
+		cur := 0
+		return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			for len(iters) > 0 {
+				if cur >= len(iters) {
+					cur = 0
+				}
+
+				ret := advance(r, s, iters[cur])
+				if s.IsInterruptedAs(FlowRaise) {
+					return ret
+				}
+
+				iteration := ret.AsIteration()
+				if AsBool(iteration.error()) {
+					return ret
+
+				} else if AsBool(iteration.done()) {
+					iters = append(iters[:cur], iters[cur+1:]...)
+					continue
+				}
+
+				cur++
+				return ret
+			}
+
+			return Iteration.DONE
+		})
+	})
+
+// ----------------------------------------------------------------------------
+// HELPERS
+// ----------------------------------------------------------------------------
+
+// Requests the next iteration of the given iterator
+func advance(r *Runtime, s *Scope, iter *Instance) *Instance {
+	return iter.AsIterator().next().OnCall(r, s, iter)
+}
+
+// Converts any iterable into an iterator, returning the error otherwise
+func iterOf(r *Runtime, s *Scope, obj *Instance) (*Instance, *Instance) {
+	iter := obj.OnIter(r, s)
+	if s.IsInterruptedAs(FlowRaise) {
+		return nil, iter
+	}
+
+	if !iter.IsIterator() {
+		return nil, throw(r, s, "cannot iterate non-iterable type '%s'", obj.Type.GetName())
+	}
+
+	return iter, nil
+}
+
+// Raises an error when the function receives more arguments than it declares,
+// unless it has a spread parameter
+func checkArgCount(r *Runtime, s *Scope, fn *Instance, args []*Instance) *Instance {
+	impl := fn.AsFunction()
+	for _, param := range impl.Params {
+		if param.Spread {
+			return nil
+		}
+	}
+
+	if len(args) > len(impl.Params) {
+		return throw(r, s, "%s does not accept additional parameters", impl.Name)
+	}
+	return nil
+}
+
+// Returns the single value of an iteration, or a tuple when the iteration
+// carries multiple values (such as dict items)
+func itemOf(iteration *IterationDataImpl) *Instance {
+	return itemOfTuple(iteration.value())
+}
+
+// Returns the single value of an iteration tuple, or the tuple itself when
+// the iteration emits several values
+func itemOfTuple(value *Instance) *Instance {
+	values := value.AsTuple().Values
+	if len(values) == 1 {
+		return values[0]
+	}
+
+	return Tuple.Create(values...)
+}
+
+// Consumes the iterator, returning the value tuple of each iteration. When
+// the iteration fails, the failed iteration (or raised error) is returned
+func drain(r *Runtime, s *Scope, iter *Instance) ([]*Instance, *Instance) {
+	values := []*Instance{}
+	for {
+		ret := advance(r, s, iter)
+		if s.IsInterruptedAs(FlowRaise) {
+			return nil, ret
+		}
+
+		iteration := ret.AsIteration()
+		if AsBool(iteration.error()) {
+			return nil, ret
+
+		} else if AsBool(iteration.done()) {
+			return values, nil
+		}
+
+		values = append(values, iteration.value())
+	}
+}
+
+// Stable sorts the values in place, ordering them by their respective keys
+// using the `lt` meta function. Returns the error raised by a comparison.
+func sortInstances(r *Runtime, s *Scope, values []*Instance, keys []*Instance, reverse bool) *Instance {
+	order := make([]int, len(values))
+	for idx := range order {
+		order[idx] = idx
+	}
+
+	var e *Instance
+	sort.SliceStable(order, func(a, b int) bool {
+		if e != nil {
+			return false
+		}
+
+		left, right := keys[order[a]], keys[order[b]]
+		if reverse {
+			left, right = right, left
+		}
+
+		ret := left.OnLt(r, s, right)
+		if s.IsInterruptedAs(FlowRaise) {
+			e = ret
+			return false
+		}
+
+		return AsBool(ret)
+	})
+
+	if e != nil {
+		return e
+	}
+
+	sorted := make([]*Instance, len(values))
+	for idx, o := range order {
+		sorted[idx] = values[o]
+	}
+	copy(values, sorted)
+
+	return nil
+}
+
+func quantifier(r *Runtime, s *Scope, any bool, args ...*Instance) *Instance {
+	i_iter, err := arg(args, 0).IsIterator().Validate()
+	if err != nil {
+		return throw(r, s, err.Error())
+	}
+
+	i_fn, err := arg(args, 1).Optional(GetFirstFn).IsFunction().Validate()
+	if err != nil {
+		return throw(r, s, err.Error())
+	}
+
+	finished := false
+	return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if finished {
+			return Iteration.DONE
+		}
+
+		for {
+			ret := advance(r, s, i_iter)
+			if s.IsInterruptedAs(FlowRaise) {
+				return ret
+			}
+
+			iteration := ret.AsIteration()
+			if AsBool(iteration.error()) {
+				return ret
+
+			} else if AsBool(iteration.done()) {
+				finished = true
+				return Iteration.Create(Boolean.Create(!any))
+			}
+
+			val := i_fn.OnCall(r, s, iteration.value().AsTuple().Values...)
+			if s.IsInterruptedAs(FlowRaise) {
+				return val
+			}
+
+			if AsBool(val) == any {
+				finished = true
+				return Iteration.Create(Boolean.Create(any))
+			}
+		}
+	})
+}
+
+// Iterates over the errors of the error Maybes (and Error values) in the
+// iterator, or over the values of the remaining items, unwrapping the Maybes
+func maybeFilter(r *Runtime, s *Scope, errors bool, args ...*Instance) *Instance {
+	i_iter, err := arg(args, 0).IsIterator().Validate()
+	if err != nil {
+		return throw(r, s, err.Error())
+	}
+
+	return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		for {
+			ret := advance(r, s, i_iter)
+			if s.IsInterruptedAs(FlowRaise) {
+				return ret
+			}
+
+			iteration := ret.AsIteration()
+			if AsBool(iteration.error()) {
+				return ret
+
+			} else if AsBool(iteration.done()) {
+				return Iteration.DONE
+			}
+
+			item := itemOf(iteration)
+			var e *Instance
+			if item.IsMaybe() {
+				e = item.AsMaybe().Error
+				if e == nil {
+					item = item.AsMaybe().Value
+				}
+
+			} else if item.IsError() {
+				e = item
+			}
+
+			if errors && e != nil {
+				return Iteration.Create(e)
+
+			} else if !errors && e == nil {
+				return Iteration.Create(item)
+			}
+		}
+	})
+}
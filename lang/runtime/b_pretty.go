@@ -0,0 +1,224 @@
+package runtime
+
+import (
+	"fmt"
+	"sht/lang/runtime/meta"
+	"sort"
+	"strings"
+)
+
+// Printed in place of a value inside itself
+const cycleRepr = "<...>"
+
+// Collections print at most this many items
+const prettyMaxItems = 100
+
+// Marks the value as being represented by the runtime, reporting false if it
+// already was, so cyclic values print as `<...>` instead of recursing
+// forever. Values represented outside of a runtime, as by Instance.Repr, get
+// a runtime of their own, which is given to their contents.
+func enterRepr(r *Runtime, value *Instance) (*Runtime, bool) {
+	if r == nil {
+		r = &Runtime{}
+	}
+	if r.reprs == nil {
+		r.reprs = map[*Instance]bool{}
+	}
+
+	if r.reprs[value] {
+		return r, false
+	}
+	r.reprs[value] = true
+	return r, true
+}
+
+func leaveRepr(r *Runtime, value *Instance) {
+	delete(r.reprs, value)
+}
+
+// Returns the repr of a value inside another one
+func reprOf(r *Runtime, s *Scope, value *Instance) string {
+	return AsString(value.Type.OnRepr(r, s, value))
+}
+
+// Prints values across several lines, breaking the collections which do not
+// fit in the width. Collections nested deeper than depth are abbreviated,
+// unless depth is negative.
+type prettyPrinter struct {
+	r      *Runtime
+	s      *Scope
+	indent int
+	depth  int
+	width  int
+	path   map[*Instance]bool // containers being printed
+}
+
+func prettyPrint(r *Runtime, s *Scope, value *Instance, indent, depth, width int) string {
+	p := &prettyPrinter{
+		r:      r,
+		s:      s,
+		indent: indent,
+		depth:  depth,
+		width:  width,
+		path:   map[*Instance]bool{},
+	}
+
+	return p.format(value, 0, 0, width)
+}
+
+// Formats the value starting at the given column. A negative width keeps
+// the value in a single line.
+func (p *prettyPrinter) format(value *Instance, level, column, width int) string {
+	open, close, items, ok := p.parts(value)
+	if !ok {
+		return AsString(value.OnRepr(p.r, p.s))
+	}
+
+	if p.path[value] {
+		return cycleRepr
+	}
+
+	if p.depth >= 0 && level >= p.depth {
+		return open + "..." + close
+	}
+
+	p.path[value] = true
+	defer delete(p.path, value)
+
+	flat := p.join(open, close, items, level, 0, -1)
+	if width < 0 || column+len(flat) <= width {
+		return flat
+	}
+
+	return p.join(open, close, items, level, (level+1)*p.indent, width)
+}
+
+// Joins the formatted items in a single line when width is negative, or one
+// per line, starting at the given column, otherwise
+func (p *prettyPrinter) join(open, close string, items []prettyItem, level, column, width int) string {
+	values := []string{}
+	for _, item := range items {
+		if item.value == nil {
+			values = append(values, item.prefix)
+			continue
+		}
+
+		start := column + len(item.prefix)
+		values = append(values, item.prefix+p.format(item.value, level+1, start, width))
+	}
+
+	if width < 0 {
+		if len(values) == 1 && open == "(" && close == ")" && items[0].prefix == "" {
+			return "(" + values[0] + ",)"
+		}
+		return open + strings.Join(values, ", ") + close
+	}
+
+	pad := strings.Repeat(" ", (level+1)*p.indent)
+	end := strings.Repeat(" ", level*p.indent)
+	return open + "\n" + pad + strings.Join(values, ",\n"+pad) + ",\n" + end + close
+}
+
+// An item of a collection, printed after its prefix. Items without value
+// only print the prefix.
+type prettyItem struct {
+	prefix string
+	value  *Instance
+}
+
+// Splits the collection in its delimiters and items, reporting false for
+// values printed by their repr
+func (p *prettyPrinter) parts(value *Instance) (string, string, []prettyItem, bool) {
+	values := func(vs []*Instance) []prettyItem {
+		items := []prettyItem{}
+		for _, v := range vs {
+			items = append(items, prettyItem{value: v})
+		}
+		return items
+	}
+
+	entries := func(entries map[string]*Instance, keyOf func(string) string) []prettyItem {
+		keys := []string{}
+		for key := range entries {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keyOf(keys[i]) < keyOf(keys[j]) })
+
+		items := []prettyItem{}
+		for _, key := range keys {
+			items = append(items, prettyItem{prefix: keyOf(key) + ": ", value: entries[key]})
+		}
+		return items
+	}
+
+	var open, close string
+	var items []prettyItem
+
+	switch {
+	case value.IsList():
+		open, close, items = "[", "]", values(value.AsList().Values)
+
+	case value.IsTuple():
+		open, close, items = "(", ")", values(value.AsTuple().Values)
+		for i, name := range value.AsTuple().Names {
+			items[i].prefix = name + ": "
+		}
+
+	case value.IsSet():
+		open, close, items = "{", "}", values(value.AsSet().values())
+
+	case value.IsDict():
+		dict := value.AsDict()
+		open, close = "{", "}"
+		items = entries(dict.Values, func(key string) string { return reprOf(p.r, p.s, dict.key(key)) })
+
+	case value.IsEnum() && len(value.AsEnum().Values) > 0:
+		this := value.AsEnum()
+		open, close, items = this.Variant.FullName()+"(", ")", values(this.Values)
+
+	case value.IsCustom() && value.Type.(*CustomType).MetaFunctions[string(meta.Repr)] == nil:
+		open, close = value.Type.GetName()+" {", "}"
+		properties := value.Type.(*CustomType).visibleProperties(p.s, value.AsCustom().Properties)
+		items = entries(properties, func(key string) string { return key })
+
+	default:
+		return "", "", nil, false
+	}
+
+	if len(items) > prettyMaxItems {
+		more := len(items) - prettyMaxItems
+		items = append(items[:prettyMaxItems], prettyItem{prefix: fmt.Sprintf("... %d more", more)})
+	}
+
+	return open, close, items, true
+}
+
+// Pretty prints the value to fit in the given width, as the REPL does
+func (r *Runtime) Pretty(value *Instance, width int) string {
+	return prettyPrint(r, r.Global, value, 2, -1, width)
+}
+
+var b_pretty = fn("pretty", p("value"), p("indent"), p("depth"), p("width")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		indent, err := arg(args, 1).Optional(Number.Create(2)).IsNumber().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		depth, err := arg(args, 2).Optional(Number.Create(-1)).IsNumber().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		width, err := arg(args, 3).Optional(Number.Create(80)).IsNumber().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		if AsInteger(indent) < 0 {
+			return throw(r, s, "pretty indent must not be negative, got %d", AsInteger(indent))
+		}
+
+		value := valueArg(args, 0)
+		return String.Create(prettyPrint(r, s, value, AsInteger(indent), AsInteger(depth), AsInteger(width)))
+	})
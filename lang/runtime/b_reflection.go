@@ -0,0 +1,238 @@
+package runtime
+
+import (
+	"sht/lang/ast"
+	"sort"
+)
+
+// Returns the given names sorted, as a list of strings
+func nameList(names map[string]bool) *Instance {
+	sorted := []string{}
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	list := []*Instance{}
+	for _, name := range sorted {
+		list = append(list, String.Create(name))
+	}
+	return List.Create(list...)
+}
+
+func propertyNames(properties map[string]ast.Node) *Instance {
+	names := map[string]bool{}
+	for name := range properties {
+		names[name] = true
+	}
+	return nameList(names)
+}
+
+func instanceNames(values ...map[string]*Instance) *Instance {
+	names := map[string]bool{}
+	for _, m := range values {
+		for name := range m {
+			names[name] = true
+		}
+	}
+	return nameList(names)
+}
+
+// Returns the value or Boolean.FALSE when the argument was not provided
+func valueArg(args []*Instance, index int) *Instance {
+	if index >= len(args) || args[index] == nil {
+		return Boolean.FALSE
+	}
+	return args[index]
+}
+
+var b_type = fn("type", p("value")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if len(args) == 0 {
+			return throw(r, s, "type requires a value")
+		}
+
+		return Type.Create(args[0].Type)
+	})
+
+var b_fields = fn("fields", p("value")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		value := valueArg(args, 0)
+		if value.IsType() {
+			dt := value.AsType().DataType
+			if custom, ok := dt.(*CustomType); ok {
+				names := map[string]bool{}
+				for name := range dt.GetProperties() {
+					if custom.canAccess(s, name) {
+						names[name] = true
+					}
+				}
+				return nameList(names)
+			}
+			return propertyNames(dt.GetProperties())
+		}
+
+		if value.IsCustom() {
+			custom := value.Type.(*CustomType)
+			return instanceNames(custom.visibleProperties(s, value.AsCustom().Properties))
+		}
+
+		if value.IsTuple() && value.AsTuple().Names != nil {
+			// named tuples keep their fields in order
+			names := []*Instance{}
+			for _, name := range value.AsTuple().Names {
+				names = append(names, String.Create(name))
+			}
+			return List.Create(names...)
+		}
+
+		return propertyNames(value.Type.GetProperties())
+	})
+
+var b_methods = fn("methods", p("value")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		value := valueArg(args, 0)
+		if value.IsType() {
+			dt := value.AsType().DataType
+			return instanceNames(dt.GetStaticFns(), dt.GetInstanceFns())
+		}
+
+		return instanceNames(value.Type.GetInstanceFns())
+	})
+
+var b_hasField = fn("hasField", p("value"), p("name")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		name, err := arg(args, 1).IsString().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		value := valueArg(args, 0)
+		field := AsString(name)
+		if value.IsType() {
+			dt := value.AsType().DataType
+			if custom, ok := dt.(*CustomType); ok && !custom.canAccess(s, field) {
+				return Boolean.FALSE
+			}
+			return Boolean.Create(dt.HasProperty(field))
+		}
+
+		if value.IsCustom() {
+			_, has := value.AsCustom().Properties[field]
+			return Boolean.Create(has && value.Type.(*CustomType).canAccess(s, field))
+		}
+
+		return Boolean.Create(value.Type.HasProperty(field))
+	})
+
+var b_getField = fn("getField", p("value"), p("name"), p("default")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		name, err := arg(args, 1).IsString().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		// private fields raise even when a default is given
+		value := valueArg(args, 0)
+		if value.IsCustom() {
+			if err := value.Type.(*CustomType).checkAccess(r, s, AsString(name)); err != nil {
+				return err
+			}
+		}
+
+		ret := value.OnGet(r, s, name)
+		if len(args) > 2 && s.IsInterruptedAs(FlowRaise) {
+			s.Interruption = nil
+			return args[2]
+		}
+		return ret
+	})
+
+var b_setField = fn("setField", p("value"), p("name"), p("newValue")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		name, err := arg(args, 1).IsString().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		if len(args) < 3 {
+			return throw(r, s, "setField requires the new value")
+		}
+
+		return valueArg(args, 0).OnSet(r, s, name, args[2])
+	})
+
+var b_isInstance = fn("isInstance", p("value"), p("types", nil, true)).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if len(args) < 2 {
+			return throw(r, s, "isInstance requires at least one type")
+		}
+
+		value := valueArg(args, 0)
+		for i := range args[1:] {
+			t, err := arg(args, i+1).IsType().Validate()
+			if err != nil {
+				return throw(r, s, err.Error())
+			}
+
+			if isInstanceOf(value, t.AsType().DataType) {
+				return Boolean.TRUE
+			}
+		}
+
+		return Boolean.FALSE
+	})
+
+var b_callable = fn("callable", p("value")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		return Boolean.Create(valueArg(args, 0).IsCallable())
+	})
+
+var b_locals = fn("locals").
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		// the variables visible inside the calling function, with the inner
+		// scopes shadowing the outer ones
+		values := map[string]*Instance{}
+		scope := s.Caller
+		for scope != nil {
+			scope.ForEach(func(name string, value *Instance) {
+				if _, has := values[name]; !has {
+					values[name] = value
+				}
+			})
+
+			if scope.Parent == nil || scope.Parent.Function != scope.Function {
+				break
+			}
+			scope = scope.Parent
+		}
+
+		return Dict.Create(values)
+	})
+
+var b_globals = fn("globals").
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		values := map[string]*Instance{}
+		r.Global.ForEach(func(name string, value *Instance) {
+			values[name] = value
+		})
+
+		return Dict.Create(values)
+	})
+
+var b_callstack = fn("callstack").
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		// the innermost call comes first, as in the error stack traces
+		stack := s.Caller.CallStack()
+		frames := []*Instance{}
+		for i := len(stack) - 1; i >= 0; i-- {
+			scope := stack[i]
+			if scope.Function == nil {
+				frames = append(frames, String.Create("<global>"))
+			} else {
+				frames = append(frames, String.Create(nameOf(scope.Function)))
+			}
+		}
+
+		return List.Create(frames...)
+	})
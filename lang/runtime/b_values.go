@@ -0,0 +1,528 @@
+package runtime
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sht/lang/runtime/meta"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Returns a canonical description of the value, equal for values with equal
+// contents. Custom instances are described by their `on hash` result, or by
+// their identity. Values already being described are marked as cycles.
+func hashKey(r *Runtime, s *Scope, value *Instance, seen map[*Instance]bool) string {
+	switch {
+	case value.IsString():
+		return "s:" + AsString(value)
+	case value.IsNumber():
+		return "n:" + strconv.FormatFloat(AsNumber(value), 'g', -1, 64)
+	case value.IsBoolean():
+		return "b:" + strconv.FormatBool(AsBool(value))
+	case value.IsType():
+		return fmt.Sprintf("T:%p", value.AsType().DataType)
+	}
+
+	if seen == nil {
+		seen = map[*Instance]bool{}
+	}
+	if seen[value] {
+		return "<cycle>"
+	}
+	seen[value] = true
+	defer delete(seen, value)
+
+	keysOf := func(values []*Instance) []string {
+		keys := []string{}
+		for _, v := range values {
+			keys = append(keys, hashKey(r, s, v, seen))
+		}
+		return keys
+	}
+
+	switch {
+	case value.IsTuple():
+		// names are left out, as tuples compare by position only
+		return "t(" + strings.Join(keysOf(value.AsTuple().Values), ",") + ")"
+
+	case value.IsList():
+		return "l[" + strings.Join(keysOf(value.AsList().Values), ",") + "]"
+
+	case value.IsSet():
+		// sets with the same values are equal, whatever their order
+		keys := keysOf(value.AsSet().values())
+		sort.Strings(keys)
+		return "S{" + strings.Join(keys, ",") + "}"
+
+	case value.IsDict():
+		entries := []string{}
+		for key, v := range value.AsDict().Values {
+			entries = append(entries, strconv.Quote(key)+"="+hashKey(r, s, v, seen))
+		}
+		sort.Strings(entries)
+		return "d{" + strings.Join(entries, ",") + "}"
+
+	case value.IsEnum():
+		this := value.AsEnum()
+		return fmt.Sprintf("e:%p:%s(%s)", this.Variant.Enum, this.Variant.Name, strings.Join(keysOf(this.Values), ","))
+
+	case value.IsCustom():
+		custom := value.Type.(*CustomType)
+		if custom.MetaFunctions[string(meta.Hash)] != nil {
+			ret := value.OnHash(r, s)
+			if s.IsInterruptedAs(FlowRaise) {
+				return ""
+			}
+			return fmt.Sprintf("h:%p:%s", custom, AsString(ret))
+		}
+
+		// frozen instances cannot change, so they are described by contents
+		if value.Frozen {
+			entries := []string{}
+			for name, v := range value.AsCustom().Properties {
+				entries = append(entries, name+"="+hashKey(r, s, v, seen))
+			}
+			sort.Strings(entries)
+			return fmt.Sprintf("c:%p{%s}", custom, strings.Join(entries, ","))
+		}
+	}
+
+	return fmt.Sprintf("i:%p", value)
+}
+
+func hashString(key string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return h.Sum32()
+}
+
+// Returns the key of the value in a map of hashed values, where equal values
+// have equal keys. Custom instances with the same `on hash` that are not
+// equal by their `on eq` are told apart by probing the entries. Reports if
+// the entries already hold an equal value.
+func hashedKey(r *Runtime, s *Scope, value *Instance, entries map[string]*Instance) (string, bool) {
+	base := "\x00" + hashKey(r, s, value, nil)
+	if s.IsInterruptedAs(FlowRaise) {
+		return base, false
+	}
+
+	for i := 0; ; i++ {
+		key := probedKey(base, i)
+		other, has := entries[key]
+		if !has {
+			return key, false
+		}
+
+		equal := keysEqual(r, s, other, value, nil)
+		if s.IsInterruptedAs(FlowRaise) {
+			return key, false
+		}
+		if equal {
+			return key, true
+		}
+	}
+}
+
+// Removes the value from a map of hashed values. The last entry probed with
+// the same hash takes the place of the removed one, so lookups don't stop
+// early; its former key is returned as moved.
+func removeHashedKey(r *Runtime, s *Scope, value *Instance, entries map[string]*Instance) (removed string, moved string, has bool) {
+	removed, has = hashedKey(r, s, value, entries)
+	if !has {
+		return removed, "", false
+	}
+
+	base := "\x00" + hashKey(r, s, value, nil)
+	last := 0
+	for entries[probedKey(base, last+1)] != nil {
+		last++
+	}
+
+	moved = probedKey(base, last)
+	entries[removed] = entries[moved]
+	delete(entries, moved)
+	if moved == removed {
+		moved = ""
+	}
+	return removed, moved, true
+}
+
+func probedKey(base string, i int) string {
+	if i == 0 {
+		return base
+	}
+	return base + "#" + strconv.Itoa(i)
+}
+
+// Compares two values with the same hash key. The key already describes them,
+// except for custom instances hashed by `on hash`, which are compared by their
+// `on eq` when they have one.
+func keysEqual(r *Runtime, s *Scope, a *Instance, b *Instance, seen map[[2]*Instance]bool) bool {
+	if a == b || a.Type != b.Type {
+		return a == b
+	}
+
+	pair := [2]*Instance{a, b}
+	if seen == nil {
+		seen = map[[2]*Instance]bool{}
+	}
+	if seen[pair] {
+		return true
+	}
+	seen[pair] = true
+
+	allEqual := func(values, others []*Instance) bool {
+		for i, v := range values {
+			if !keysEqual(r, s, v, others[i], seen) || s.IsInterruptedAs(FlowRaise) {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch {
+	case a.IsList():
+		return allEqual(a.AsList().Values, b.AsList().Values)
+
+	case a.IsTuple():
+		return allEqual(a.AsTuple().Values, b.AsTuple().Values)
+
+	case a.IsEnum():
+		return allEqual(a.AsEnum().Values, b.AsEnum().Values)
+
+	case a.IsDict():
+		other := b.AsDict()
+		for key, v := range a.AsDict().Values {
+			if !keysEqual(r, s, v, other.Values[key], seen) || s.IsInterruptedAs(FlowRaise) {
+				return false
+			}
+		}
+		return true
+
+	case a.IsSet():
+		return a.AsSet().subsetOf(r, s, b.AsSet())
+
+	case a.IsCustom():
+		custom := a.Type.(*CustomType)
+		if custom.MetaFunctions[string(meta.Hash)] != nil {
+			if custom.MetaFunctions[string(meta.Eq)] == nil {
+				return true
+			}
+			return AsBool(a.OnEq(r, s, b))
+		}
+
+		other := b.AsCustom().Properties
+		for name, v := range a.AsCustom().Properties {
+			if !keysEqual(r, s, v, other[name], seen) || s.IsInterruptedAs(FlowRaise) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return true
+}
+
+// Returns a frozen copy of the lists, dicts and sets in the value, so a hashed
+// entry keeps the contents it was added with. Other values are shared.
+func keySnapshot(value *Instance, memo map[*Instance]*Instance) *Instance {
+	if !value.IsList() && !value.IsDict() && !value.IsSet() && !value.IsTuple() {
+		return value
+	}
+
+	if memo == nil {
+		memo = map[*Instance]*Instance{}
+	}
+	if copied, has := memo[value]; has {
+		return copied
+	}
+
+	innerAll := func(values []*Instance) []*Instance {
+		copies := make([]*Instance, len(values))
+		for i, v := range values {
+			copies[i] = keySnapshot(v, memo)
+		}
+		return copies
+	}
+
+	var copied *Instance
+	switch {
+	case value.IsList():
+		copied = List.Create()
+		memo[value] = copied
+		copied.AsList().Values = innerAll(value.AsList().Values)
+
+	case value.IsTuple():
+		copied = Tuple.CreateNamed(value.AsTuple().Names)
+		memo[value] = copied
+		copied.AsTuple().Values = innerAll(value.AsTuple().Values)
+
+	case value.IsDict():
+		this := value.AsDict()
+		copied = Dict.Create(map[string]*Instance{})
+		memo[value] = copied
+		for key, v := range this.Values {
+			copied.AsDict().Values[key] = keySnapshot(v, memo)
+		}
+		for key, original := range this.Keys {
+			copied.AsDict().Keys[key] = original
+		}
+
+	default:
+		// set values are snapshots already
+		this := value.AsSet()
+		copied = Set.Create()
+		memo[value] = copied
+		impl := copied.AsSet()
+		impl.Keys = append(impl.Keys, this.Keys...)
+		for key, v := range this.Values {
+			impl.Values[key] = v
+		}
+	}
+
+	copied.Frozen = true
+	return copied
+}
+
+// Compares the values by content, descending into lists, dicts and tuples.
+// Pairs of values already being compared are assumed equal, so cyclic
+// structures can be compared.
+func deepEqual(r *Runtime, s *Scope, a *Instance, b *Instance, seen map[[2]*Instance]bool) bool {
+	if a == b || a.Type == WildCard.Type || b.Type == WildCard.Type {
+		return true
+	}
+
+	isContainer := a.IsList() || a.IsDict() || a.IsTuple()
+	if !isContainer {
+		return AsBool(a.OnEq(r, s, b))
+	}
+
+	if a.Type != b.Type {
+		return false
+	}
+
+	pair := [2]*Instance{a, b}
+	if seen == nil {
+		seen = map[[2]*Instance]bool{}
+	}
+	if seen[pair] {
+		return true
+	}
+	seen[pair] = true
+
+	allEqual := func(values, others []*Instance) bool {
+		if len(values) != len(others) {
+			return false
+		}
+
+		for i, v := range values {
+			if !deepEqual(r, s, v, others[i], seen) || s.IsInterruptedAs(FlowRaise) {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch {
+	case a.IsList():
+		return allEqual(a.AsList().Values, b.AsList().Values)
+
+	case a.IsTuple():
+		// tuples compare by position, whatever their names
+		return allEqual(a.AsTuple().Values, b.AsTuple().Values)
+
+	default:
+		this := a.AsDict()
+		other := b.AsDict()
+		if len(this.Values) != len(other.Values) {
+			return false
+		}
+
+		for key, v := range this.Values {
+			o, has := other.Values[key]
+			if !has || !deepEqual(r, s, v, o, seen) || s.IsInterruptedAs(FlowRaise) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Copies the value, copying its contents too when memo is given, so shared
+// and cyclic references keep their shape. The memo maps the values already
+// copied to their copies. Custom instances with `on copy` are copied by it,
+// without descending into them.
+func copyValue(r *Runtime, s *Scope, value *Instance, memo map[*Instance]*Instance) *Instance {
+	if copied, has := memo[value]; has {
+		return copied
+	}
+
+	inner := func(v *Instance) *Instance {
+		if memo == nil {
+			return v
+		}
+		return copyValue(r, s, v, memo)
+	}
+
+	innerAll := func(values []*Instance) []*Instance {
+		copies := make([]*Instance, len(values))
+		for i, v := range values {
+			copies[i] = inner(v)
+		}
+		return copies
+	}
+
+	register := func(copied *Instance) *Instance {
+		if memo != nil {
+			memo[value] = copied
+		}
+		return copied
+	}
+
+	switch {
+	case value.IsList():
+		this := value.AsList()
+		copied := register(List.Create())
+		copied.AsList().Properties["default"] = this.default_()
+		copied.AsList().Values = innerAll(this.Values)
+		return copied
+
+	case value.IsTuple():
+		copied := register(Tuple.CreateNamed(value.AsTuple().Names))
+		copied.AsTuple().Values = innerAll(value.AsTuple().Values)
+		return copied
+
+	case value.IsDict():
+		this := value.AsDict()
+		copied := register(Dict.Create(map[string]*Instance{}))
+		impl := copied.AsDict()
+		impl.Properties["default"] = this.default_()
+		for key, v := range this.Values {
+			impl.Values[key] = inner(v)
+		}
+		for key, original := range this.Keys {
+			impl.Keys[key] = original
+		}
+		return copied
+
+	case value.IsSet():
+		copied := register(Set.Create())
+		for _, v := range value.AsSet().values() {
+			copied.AsSet().add(r, s, inner(v))
+		}
+		return copied
+
+	case value.IsEnum():
+		this := value.AsEnum()
+		if len(this.Values) == 0 {
+			return value
+		}
+		copied := register(this.Variant.Create())
+		copied.AsEnum().Values = innerAll(this.Values)
+		return copied
+
+	case value.IsCustom():
+		custom := value.Type.(*CustomType)
+		if fn := custom.MetaFunctions[string(meta.Copy)]; fn != nil {
+			return register(fn.OnCall(r, s, value))
+		}
+
+		properties := map[string]*Instance{}
+		copied := register(&Instance{
+			Type: value.Type,
+			Impl: &CustomImpl{
+				Properties: properties,
+			},
+		})
+		for name, v := range value.AsCustom().Properties {
+			properties[name] = inner(v)
+		}
+		return copied
+	}
+
+	// numbers, strings, functions and other immutable values are shared
+	return value
+}
+
+var b_hash = fn("hash", p("value")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		return valueArg(args, 0).OnHash(r, s)
+	})
+
+var b_copy = fn("copy", p("value")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		return copyValue(r, s, valueArg(args, 0), nil)
+	})
+
+var b_deepCopy = fn("deepCopy", p("value")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		return copyValue(r, s, valueArg(args, 0), map[*Instance]*Instance{})
+	})
+
+// Makes the collection or data instance read-only, also freezing the values
+// inside it when deep is set
+func freezeValue(value *Instance, deep bool, seen map[*Instance]bool) {
+	if seen[value] {
+		return
+	}
+	seen[value] = true
+
+	var inner []*Instance
+	switch {
+	case value.IsList():
+		value.Frozen = true
+		inner = value.AsList().Values
+	case value.IsDict():
+		value.Frozen = true
+		for _, v := range value.AsDict().Values {
+			inner = append(inner, v)
+		}
+	case value.IsSet():
+		value.Frozen = true
+		inner = value.AsSet().values()
+	case value.IsCustom():
+		value.Frozen = true
+		for _, v := range value.AsCustom().Properties {
+			inner = append(inner, v)
+		}
+	case value.IsTuple():
+		inner = value.AsTuple().Values
+	case value.IsEnum():
+		inner = value.AsEnum().Values
+	}
+
+	if !deep {
+		return
+	}
+
+	for _, v := range inner {
+		freezeValue(v, deep, seen)
+	}
+}
+
+var b_freeze = fn("freeze", p("value"), p("deep", Boolean.FALSE)).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		deep, err := arg(args, 1).Optional(Boolean.FALSE).IsBoolean().Validate()
+		if err != nil {
+			return throw(r, s, err.Error())
+		}
+
+		value := valueArg(args, 0)
+		freezeValue(value, AsBool(deep), map[*Instance]bool{})
+		return value
+	})
+
+var b_isFrozen = fn("isFrozen", p("value")).
+	as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		value := valueArg(args, 0)
+		switch {
+		case value.IsList() || value.IsDict() || value.IsSet() || value.IsCustom():
+			return Boolean.Create(value.Frozen)
+		case value.IsNumber() || value.IsString() || value.IsBoolean() || value.IsTuple() || value.IsEnum():
+			// values of immutable types never change
+			return Boolean.TRUE
+		}
+
+		return Boolean.FALSE
+	})
@@ -51,14 +51,31 @@ func throw(r *Runtime, s *Scope, msg string, args ...any) *Instance {
 	return r.Throw(Error.Create(s, msg, args...), s)
 }
 
+// Consumes the given iterable, returning one item per iteration or the error
+// raised during the iteration
+func collect(r *Runtime, s *Scope, obj *Instance) ([]*Instance, *Instance) {
+	values := []*Instance{}
+	var e *Instance
+	r.ResolveIterator(obj, s, func(v *Instance, err *Instance) {
+		if err != nil {
+			e = err
+		} else if v != nil {
+			values = append(values, itemOfTuple(v))
+		}
+	})
+
+	return values, e
+}
+
 // ----------------------------------------------------------------------------
 
 type BuiltinArg struct {
-	args     []*Instance
-	index    int
-	optional bool
-	default_ *Instance
-	types    []string
+	args      []*Instance
+	index     int
+	optional  bool
+	default_  *Instance
+	types     []string
+	protocols []*ProtocolType
 }
 
 func arg(args []*Instance, index int) *BuiltinArg {
@@ -111,6 +128,16 @@ func (b *BuiltinArg) IsTuple() *BuiltinArg {
 	return b
 }
 
+func (b *BuiltinArg) IsType() *BuiltinArg {
+	b.types = []string{"Type"}
+	return b
+}
+
+func (b *BuiltinArg) Implements(protocol *ProtocolType) *BuiltinArg {
+	b.protocols = append(b.protocols, protocol)
+	return b
+}
+
 func (b *BuiltinArg) OrString() *BuiltinArg {
 	b.types = append(b.types, "String")
 	return b
@@ -156,6 +183,12 @@ func (b *BuiltinArg) Validate() (*Instance, error) {
 
 	arg := b.args[b.index]
 
+	for _, protocol := range b.protocols {
+		if !protocol.IsImplementedBy(arg) {
+			return nil, fmt.Errorf("Expecting argument at index '%d' to implement '%s', got '%s'", b.index, protocol.Name, arg.Type.GetName())
+		}
+	}
+
 	ok := false
 	if len(b.types) == 0 {
 		return arg, nil
@@ -197,6 +230,11 @@ func (b *BuiltinArg) Validate() (*Instance, error) {
 				ok = true
 				break
 			}
+		case "Type":
+			if arg.IsType() {
+				ok = true
+				break
+			}
 		}
 	}
 
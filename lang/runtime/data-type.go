@@ -35,6 +35,8 @@ type DataType interface {
 	OnIn(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance
 	OnIs(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance
 	OnIter(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance
+	OnClose(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance
+	OnHash(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance
 	OnAdd(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance
 	OnSub(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance
 	OnMul(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance
@@ -155,26 +157,32 @@ func (d *BaseDataType) OnIs(r *Runtime, s *Scope, self *Instance, args ...*Insta
 func (d *BaseDataType) OnIter(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	return r.Throw(Error.InvalidAction(s, string(meta.Iter), self), s)
 }
+func (d *BaseDataType) OnClose(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return r.Throw(Error.InvalidAction(s, string(meta.Close), self), s)
+}
+func (d *BaseDataType) OnHash(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return Number.Create(float64(hashString(hashKey(r, s, self, nil))))
+}
 func (d *BaseDataType) OnAdd(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
-	return r.Throw(Error.InvalidOperation(s, string(meta.Add), self), s)
+	return reflectOperator(r, s, meta.Add, self, args[0], Error.InvalidOperation(s, string(meta.Add), self))
 }
 func (d *BaseDataType) OnSub(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
-	return r.Throw(Error.InvalidOperation(s, string(meta.Sub), self), s)
+	return reflectOperator(r, s, meta.Sub, self, args[0], Error.InvalidOperation(s, string(meta.Sub), self))
 }
 func (d *BaseDataType) OnMul(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
-	return r.Throw(Error.InvalidOperation(s, string(meta.Mul), self), s)
+	return reflectOperator(r, s, meta.Mul, self, args[0], Error.InvalidOperation(s, string(meta.Mul), self))
 }
 func (d *BaseDataType) OnDiv(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
-	return r.Throw(Error.InvalidOperation(s, string(meta.Div), self), s)
+	return reflectOperator(r, s, meta.Div, self, args[0], Error.InvalidOperation(s, string(meta.Div), self))
 }
 func (d *BaseDataType) OnIntDiv(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
-	return r.Throw(Error.InvalidOperation(s, string(meta.IntDiv), self), s)
+	return reflectOperator(r, s, meta.IntDiv, self, args[0], Error.InvalidOperation(s, string(meta.IntDiv), self))
 }
 func (d *BaseDataType) OnMod(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
-	return r.Throw(Error.InvalidOperation(s, string(meta.Mod), self), s)
+	return reflectOperator(r, s, meta.Mod, self, args[0], Error.InvalidOperation(s, string(meta.Mod), self))
 }
 func (d *BaseDataType) OnPow(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
-	return r.Throw(Error.InvalidOperation(s, string(meta.Pow), self), s)
+	return reflectOperator(r, s, meta.Pow, self, args[0], Error.InvalidOperation(s, string(meta.Pow), self))
 }
 func (d *BaseDataType) OnEq(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	if self == args[0] {
@@ -216,3 +224,16 @@ func (d *BaseDataType) OnNot(r *Runtime, s *Scope, self *Instance, args ...*Inst
 
 	return Boolean.TRUE
 }
+
+// Calls the reflected meta function of the right operand when the left one
+// cannot handle the operation, such as `2 * vector`, raising the given error
+// if the right operand does not implement it either
+func reflectOperator(r *Runtime, s *Scope, name meta.MetaName, self *Instance, other *Instance, err *Instance) *Instance {
+	if custom, ok := other.Type.(*CustomType); ok {
+		if fn := custom.MetaFunctions[string(meta.Reflected(name))]; fn != nil {
+			return fn.OnCall(r, s, other, self)
+		}
+	}
+
+	return r.Throw(err, s)
+}
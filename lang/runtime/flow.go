@@ -14,4 +14,5 @@ type FlowInterruption struct {
 	Origin *Scope
 	Type   FlowType
 	Value  *Instance
+	Label  string // loop targeted by break and continue, if any
 }
@@ -1,7 +1,10 @@
 package runtime
 
+import "sht/lang/runtime/meta"
+
 type Instance struct {
 	Constant bool
+	Frozen   bool // the contents of the value cannot be changed
 	Type     DataType
 	Impl     DataImpl
 	MemberOf *Instance
@@ -15,6 +18,14 @@ func (i *Instance) AsCustom() *CustomImpl {
 	return i.Impl.(*CustomImpl)
 }
 
+func (i *Instance) IsEnum() bool {
+	_, ok := i.Type.(*EnumType)
+	return ok
+}
+func (i *Instance) AsEnum() *EnumDataImpl {
+	return i.Impl.(*EnumDataImpl)
+}
+
 func (i *Instance) IsBoolean() bool {
 	return i.Type == Boolean.Type
 }
@@ -57,6 +68,34 @@ func (i *Instance) AsList() *ListDataImpl {
 	return i.Impl.(*ListDataImpl)
 }
 
+func (i *Instance) IsSet() bool {
+	return i.Type == Set.Type
+}
+func (i *Instance) AsSet() *SetDataImpl {
+	return i.Impl.(*SetDataImpl)
+}
+
+func (i *Instance) IsSlice() bool {
+	return i.Type == Slice.Type
+}
+func (i *Instance) AsSlice() *SliceDataImpl {
+	return i.Impl.(*SliceDataImpl)
+}
+
+func (i *Instance) IsSuper() bool {
+	return i.Type == Super.Type
+}
+func (i *Instance) AsSuper() *SuperDataImpl {
+	return i.Impl.(*SuperDataImpl)
+}
+
+func (i *Instance) IsKeywordArgument() bool {
+	return i.Type == KeywordArgument.Type
+}
+func (i *Instance) AsKeywordArgument() *KeywordArgumentDataImpl {
+	return i.Impl.(*KeywordArgumentDataImpl)
+}
+
 func (i *Instance) IsType() bool {
 	return i.Type == Type.Type
 }
@@ -85,6 +124,17 @@ func (i *Instance) AsFunction() *FunctionDataImpl {
 	return i.Impl.(*FunctionDataImpl)
 }
 
+// Reports if the instance can be called: functions, types and custom
+// instances implementing `on call`
+func (i *Instance) IsCallable() bool {
+	if i.IsFunction() || i.Type == Type.Type {
+		return true
+	}
+
+	custom, ok := i.Type.(*CustomType)
+	return ok && i.Impl != nil && custom.MetaFunctions[string(meta.Call)] != nil
+}
+
 func (i *Instance) IsError() bool {
 	return i.Type == Error.Type
 }
@@ -147,6 +197,12 @@ func (i *Instance) OnIs(r *Runtime, s *Scope, args ...*Instance) *Instance {
 func (i *Instance) OnIter(r *Runtime, s *Scope, args ...*Instance) *Instance {
 	return i.Type.OnIter(r, s, i, args...)
 }
+func (i *Instance) OnClose(r *Runtime, s *Scope, args ...*Instance) *Instance {
+	return i.Type.OnClose(r, s, i, args...)
+}
+func (i *Instance) OnHash(r *Runtime, s *Scope, args ...*Instance) *Instance {
+	return i.Type.OnHash(r, s, i, args...)
+}
 func (i *Instance) OnAdd(r *Runtime, s *Scope, args ...*Instance) *Instance {
 	return i.Type.OnAdd(r, s, i, args...)
 }
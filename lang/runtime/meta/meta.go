@@ -21,8 +21,11 @@ var (
 	To      MetaName = "to" // <value> to <type> 		! STATIC
 
 	// Other meta
-	Iter MetaName = "iter" // for i in x
-	Len  MetaName = "len"
+	Iter  MetaName = "iter" // for i in x
+	Len   MetaName = "len"
+	Close MetaName = "close" // with x := ... { }
+	Hash  MetaName = "hash"  // hash(x), dict keys
+	Copy  MetaName = "copy"  // copy(x), deepCopy(x)
 	// Bang MetaName = "bang" // !
 
 	// Operators
@@ -44,8 +47,41 @@ var (
 	Not    MetaName = "not"    // !
 	In     MetaName = "in"     // in
 	Is     MetaName = "is"     // is
+
+	// Reflected operators, called on the right operand when the left one
+	// cannot handle it
+	RAdd    MetaName = "radd"    // +
+	RSub    MetaName = "rsub"    // -
+	RMul    MetaName = "rmul"    // *
+	RDiv    MetaName = "rdiv"    // /
+	RIntDiv MetaName = "rintDiv" // //
+	RMod    MetaName = "rmod"    // %
+	RPow    MetaName = "rpow"    // **
 )
 
+// Returns the reflected version of the operator meta function, or an empty
+// name if the operator has none
+func Reflected(name MetaName) MetaName {
+	switch name {
+	case Add:
+		return RAdd
+	case Sub:
+		return RSub
+	case Mul:
+		return RMul
+	case Div:
+		return RDiv
+	case IntDiv:
+		return RIntDiv
+	case Mod:
+		return RMod
+	case Pow:
+		return RPow
+	}
+
+	return ""
+}
+
 func FromUnaryOperator(op string) MetaName {
 	switch op {
 	case "+":
@@ -94,7 +130,7 @@ func FromBinaryOperator(op string) MetaName {
 
 func IsValid(name string) bool {
 	switch MetaName(name) {
-	case SetProperty, GetProperty, SetItem, GetItem, Len, New, Call, Number, Boolean, String, Repr, To, Iter, Add, Sub, Mul, Div, IntDiv, Mod, Pow, Eq, Neq, Gt, Lt, Gte, Lte, Pos, Neg, Not, Is, In:
+	case SetProperty, GetProperty, SetItem, GetItem, Len, Close, Hash, Copy, New, Call, Number, Boolean, String, Repr, To, Iter, Add, Sub, Mul, Div, IntDiv, Mod, Pow, Eq, Neq, Gt, Lt, Gte, Lte, Pos, Neg, Not, Is, In, RAdd, RSub, RMul, RDiv, RIntDiv, RMod, RPow:
 		return true
 	}
 
@@ -19,9 +19,15 @@ type MatchRecord struct {
 
 type ForRecord struct {
 	Scope *Scope
+	Else  bool
+}
+
+type WithRecord struct {
+	Scope *Scope
 }
 
 type PipeLoopRecord struct {
 	Scope    *Scope
 	Iterator *Instance
+	Else     bool
 }
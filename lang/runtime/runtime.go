@@ -2,26 +2,59 @@ package runtime
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
 	"sht/lang/ast"
+	"sht/lang/runtime/meta"
+	"strings"
+	"time"
 )
 
 type Runtime struct {
 	Global *Scope
+
+	// writer of the calls logged by `trace`, the standard output by default
+	TraceOutput io.Writer
+	traceDepth  int // nesting of the calls logged by `trace`
+
+	// writer of the warnings, such as deprecated calls, the standard error by
+	// default
+	WarningOutput io.Writer
+
+	// generator shared by the random functions of the runtime, so a call to
+	// `random.seed` makes every following call reproducible
+	random *rand.Rand
+
+	// values whose repr is being built, to cut cycles
+	reprs map[*Instance]bool
+
+	// matches already warned about not covering every enum variant
+	warnedMatches map[*ast.Match]bool
 }
 
 func CreateRuntime() *Runtime {
-	r := &Runtime{}
+	r := &Runtime{
+		TraceOutput:   os.Stdout,
+		WarningOutput: os.Stderr,
+		random:        rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
 
 	Boolean.Setup()
 	Dict.Setup()
 	Error.Setup()
 	Iteration.Setup()
 	Iterator.Setup()
+	KeywordArgument.Setup()
 	Function.Setup()
 	List.Setup()
 	Maybe.Setup()
 	Number.Setup()
+	Set.Setup()
+	Slice.Setup()
 	String.Setup()
+	Super.Setup()
 	Tuple.Setup()
 	Type.Setup()
 	WildCard.Setup()
@@ -38,10 +71,16 @@ func CreateRuntime() *Runtime {
 	r.Global.Set(List.Type.GetName(), Constant(List.TypeInstance))
 	r.Global.Set(Maybe.Type.GetName(), Constant(Maybe.TypeInstance))
 	r.Global.Set(Number.Type.GetName(), Constant(Number.TypeInstance))
+	r.Global.Set(Set.Type.GetName(), Constant(Set.TypeInstance))
+	r.Global.Set(Slice.Type.GetName(), Constant(Slice.TypeInstance))
 	r.Global.Set(String.Type.GetName(), Constant(String.TypeInstance))
 	r.Global.Set(Tuple.Type.GetName(), Constant(Tuple.TypeInstance))
 	r.Global.Set(Type.Type.GetName(), Constant(Type.TypeInstance))
 
+	r.Global.Set(Iterable.GetName(), Constant(Type.Create(Iterable)))
+	r.Global.Set(Sized.GetName(), Constant(Type.Create(Sized)))
+	r.Global.Set(Callable.GetName(), Constant(Type.Create(Callable)))
+
 	r.Global.Set("Done", Constant(Iteration.DONE))
 	r.Global.Set("map", Constant(b_map))
 	r.Global.Set("each", Constant(b_each))
@@ -56,6 +95,26 @@ func CreateRuntime() *Runtime {
 	r.Global.Set("last", Constant(b_last))
 	r.Global.Set("window", Constant(b_window))
 	r.Global.Set("multiply", Constant(b_multiply))
+	r.Global.Set("zip", Constant(b_zip))
+	r.Global.Set("enumerate", Constant(b_enumerate))
+	r.Global.Set("chain", Constant(b_chain))
+	r.Global.Set("flatMap", Constant(b_flatMap))
+	r.Global.Set("skip", Constant(b_skip))
+	r.Global.Set("skipWhile", Constant(b_skipWhile))
+	r.Global.Set("chunk", Constant(b_chunk))
+	r.Global.Set("distinct", Constant(b_distinct))
+	r.Global.Set("groupBy", Constant(b_groupBy))
+	r.Global.Set("partition", Constant(b_partition))
+	r.Global.Set("scan", Constant(b_scan))
+	r.Global.Set("oks", Constant(b_oks))
+	r.Global.Set("errors", Constant(b_errors))
+	r.Global.Set("count", Constant(b_count))
+	r.Global.Set("any", Constant(b_any))
+	r.Global.Set("all", Constant(b_all))
+	r.Global.Set("sortBy", Constant(b_sortBy))
+	r.Global.Set("reverse", Constant(b_reverse))
+	r.Global.Set("cycle", Constant(b_cycle))
+	r.Global.Set("interleave", Constant(b_interleave))
 
 	r.Global.Set("range", Constant(b_range))
 
@@ -65,25 +124,58 @@ func CreateRuntime() *Runtime {
 	r.Global.Set("iter", Constant(b_iter))
 	r.Global.Set("palindrome", Constant(b_palindrome))
 
+	r.Global.Set("type", Constant(b_type))
+	r.Global.Set("fields", Constant(b_fields))
+	r.Global.Set("methods", Constant(b_methods))
+	r.Global.Set("hasField", Constant(b_hasField))
+	r.Global.Set("getField", Constant(b_getField))
+	r.Global.Set("setField", Constant(b_setField))
+	r.Global.Set("isInstance", Constant(b_isInstance))
+	r.Global.Set("callable", Constant(b_callable))
+	r.Global.Set("locals", Constant(b_locals))
+	r.Global.Set("globals", Constant(b_globals))
+	r.Global.Set("callstack", Constant(b_callstack))
+	r.Global.Set("hash", Constant(b_hash))
+	r.Global.Set("copy", Constant(b_copy))
+	r.Global.Set("deepCopy", Constant(b_deepCopy))
+	r.Global.Set("freeze", Constant(b_freeze))
+	r.Global.Set("isFrozen", Constant(b_isFrozen))
+	r.Global.Set("pretty", Constant(b_pretty))
+
+	r.Global.Set("memoize", Constant(b_memoize))
+	r.Global.Set("trace", Constant(b_trace))
+	r.Global.Set("deprecated", Constant(b_deprecated))
+
 	r.Global.Set("math", Constant(b_m_math))
+	r.Global.Set("random", Constant(b_m_random))
 
 	return r
 }
 
 func (r *Runtime) Run(node ast.Node) (string, error) {
+	instance, err := r.Execute(node)
+	if err != nil {
+		return "", err
+	}
+
+	return instance.Repr(), nil
+}
+
+// Evaluates the program, returning its result or the error it raised
+func (r *Runtime) Execute(node ast.Node) (*Instance, error) {
 	instance := r.Eval(node, r.Global)
 
 	if r.Global.IsInterruptedAs(FlowRaise) {
 		r.Global.Interruption = nil
-		return "", errors.New(r.Global.Interruption.Value.Repr())
+		return nil, errors.New(r.Global.Interruption.Value.Repr())
 	}
 
 	if instance.IsError() {
-		return "", errors.New(instance.Repr())
+		return nil, errors.New(instance.Repr())
 	}
 
 	r.Global.Interruption = nil
-	return instance.Repr(), nil
+	return instance, nil
 }
 
 func (r *Runtime) Eval(node ast.Node, scope *Scope) *Instance {
@@ -95,6 +187,13 @@ func (r *Runtime) Eval(node ast.Node, scope *Scope) *Instance {
 		return Boolean.FALSE
 	}
 
+	gen := scope.Generator
+	if gen != nil {
+		if value, completed := gen.enter(node); completed {
+			return value
+		}
+	}
+
 	scope.PushNode(node)
 	var result *Instance
 	switch n := node.(type) {
@@ -154,12 +253,27 @@ func (r *Runtime) Eval(node ast.Node, scope *Scope) *Instance {
 	case *ast.Indexing:
 		result = r.EvalIndexing(n, scope)
 
+	case *ast.Slice:
+		result = r.EvalSlice(n, scope)
+
+	case *ast.KeywordArgument:
+		result = r.EvalKeywordArgument(n, scope)
+
 	case *ast.Wrapping:
 		result = r.EvalWrapping(n, scope)
 
 	case *ast.Unwrapping:
 		result = r.EvalUnwrap(n, scope)
 
+	case *ast.Try:
+		result = r.EvalTry(n, scope)
+
+	case *ast.Defer:
+		result = r.EvalDefer(n, scope)
+
+	case *ast.With:
+		result = r.EvalWith(n, scope)
+
 	case *ast.If:
 		result = r.EvalIf(n, scope)
 
@@ -172,27 +286,46 @@ func (r *Runtime) Eval(node ast.Node, scope *Scope) *Instance {
 	case *ast.SpreadOut:
 		result = r.EvalSpreadOut(n, scope)
 
+	case *ast.Super:
+		result = r.EvalSuper(n, scope)
+
+	case *ast.Comprehension:
+		result = r.EvalComprehension(n, scope)
+
 	case *ast.Access:
 		result = r.EvalAccess(n, scope)
 
 	case *ast.Pipe:
 		result = r.EvalPipe(n, scope)
 
+	case *ast.Forward:
+		result = r.EvalForward(n, scope)
+
 	case *ast.PipeLoop:
 		result = r.EvalPipeLoop(n, scope)
 
 	case *ast.DataDef:
 		result = r.EvalDataDef(n, scope)
 
+	case *ast.ProtocolDef:
+		result = r.EvalProtocolDef(n, scope)
+
+	case *ast.EnumDef:
+		result = r.EvalEnumDef(n, scope)
+
 	}
 
 	scope.PopNode()
 
-	if result != nil {
-		return result
+	if result == nil {
+		result = Boolean.FALSE
+	}
+
+	if gen != nil {
+		gen.leave(node, result, scope)
 	}
 
-	return Boolean.FALSE
+	return result
 }
 
 func (r *Runtime) Throw(err *Instance, scope *Scope) *Instance {
@@ -221,35 +354,38 @@ func (r *Runtime) EvalBlock(node *ast.Block, scope *Scope) *Instance {
 	scope.ActiveRecord = nil
 
 	var result *Instance
+	suspended := false
 	for i := currentStatement; i < len(node.Statements); i++ {
 		stmt := node.Statements[i]
 		result = r.Eval(stmt, newScope)
 
 		if newScope.IsInterruptedAs(FlowRaise, FlowContinue, FlowBreak, FlowReturn) {
-			if newScope.IsInterruptedAs(FlowRaise) {
-				result = newScope.Interruption.Value
-			}
-
-			newScope.Propagate()
 			break
 
 		} else if newScope.IsInterruptedAs(FlowYield) {
-			cur := i
-			if newScope.Interruption.Origin == newScope {
-				cur += 1
-			}
-
+			// the statement runs again when resumed, replaying the values
+			// computed before the pending yield, which evaluates to the
+			// value sent to the generator
 			scope.ActiveRecord = &BlockRecord{
 				Scope:   newScope,
-				Current: cur,
+				Current: i,
 			}
 
-			newScope.Propagate()
+			suspended = true
 			break
 		}
 		newScope.ActiveRecord = nil
 	}
 
+	if !suspended {
+		r.RunDeferred(newScope)
+	}
+
+	if newScope.IsInterruptedAs(FlowRaise) {
+		result = newScope.Interruption.Value
+	}
+	newScope.Propagate()
+
 	if result == nil {
 		return Boolean.FALSE
 	}
@@ -257,6 +393,74 @@ func (r *Runtime) EvalBlock(node *ast.Block, scope *Scope) *Instance {
 	return result
 }
 
+// Calls the deferred functions of the scope in reverse order. The scope
+// interruption is kept, unless a deferred function raises an error.
+func (r *Runtime) RunDeferred(scope *Scope) {
+	interruption := scope.Interruption
+	for len(scope.Deferred) > 0 {
+		last := len(scope.Deferred) - 1
+		fn := scope.Deferred[last]
+		scope.Deferred = scope.Deferred[:last]
+
+		scope.Interruption = nil
+		fn()
+		if scope.IsInterruptedAs(FlowRaise) {
+			interruption = scope.Interruption
+		}
+	}
+
+	scope.Interruption = interruption
+}
+
+func (r *Runtime) EvalDefer(node *ast.Defer, scope *Scope) *Instance {
+	scope.Deferred = append(scope.Deferred, func() {
+		r.Eval(node.Expression, scope)
+	})
+
+	return Boolean.FALSE
+}
+
+func (r *Runtime) EvalWith(node *ast.With, scope *Scope) *Instance {
+	var newScope *Scope
+	if scope.ActiveRecord != nil {
+		state := scope.ActiveRecord.(*WithRecord)
+		newScope = state.Scope
+	} else {
+		newScope = CreateScope(scope, scope.Caller, scope)
+		newScope.Name = "with"
+
+		resource := r.Eval(node.Expression, newScope)
+		if newScope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+			return newScope.Propagate()
+		}
+
+		if node.Name != "" {
+			newScope.Set(node.Name, resource)
+		}
+
+		newScope.Deferred = append(newScope.Deferred, func() {
+			resource.OnClose(r, newScope)
+		})
+	}
+	scope.ActiveRecord = nil
+
+	result := r.Eval(node.Body, newScope)
+	if newScope.IsInterruptedAs(FlowYield) {
+		scope.ActiveRecord = &WithRecord{
+			Scope: newScope,
+		}
+		return newScope.Propagate()
+	}
+
+	r.RunDeferred(newScope)
+	if newScope.IsInterruptedAs(FlowRaise) {
+		result = newScope.Interruption.Value
+	}
+	newScope.Propagate()
+
+	return result
+}
+
 func (r *Runtime) EvalNumber(node *ast.Number, scope *Scope) *Instance {
 	return Number.Create(node.Value)
 }
@@ -272,11 +476,27 @@ func (r *Runtime) EvalString(node *ast.String, scope *Scope) *Instance {
 func (r *Runtime) EvalTuple(node *ast.Tuple, scope *Scope) *Instance {
 	values := make([]*Instance, 0)
 
+	if node.Names != nil {
+		for _, v := range node.Values {
+			value := r.Eval(v, scope)
+			if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+				return value
+			}
+			values = append(values, value)
+		}
+
+		return Tuple.CreateNamed(node.Names, values...)
+	}
+
 	for _, v := range node.Values {
 		s, isSpread := v.(*ast.SpreadOut)
 
 		if isSpread {
 			spread := r.Eval(s.Target, scope)
+			if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+				return spread
+			}
+
 			var e *Instance
 			r.ResolveIterator(spread, scope, func(v *Instance, err *Instance) {
 				if err != nil {
@@ -292,7 +512,11 @@ func (r *Runtime) EvalTuple(node *ast.Tuple, scope *Scope) *Instance {
 			}
 
 		} else {
-			values = append(values, r.Eval(v, scope))
+			value := r.Eval(v, scope)
+			if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+				return value
+			}
+			values = append(values, value)
 		}
 	}
 
@@ -301,6 +525,9 @@ func (r *Runtime) EvalTuple(node *ast.Tuple, scope *Scope) *Instance {
 
 func (r *Runtime) EvalUnaryOperator(node *ast.UnaryOperator, scope *Scope) *Instance {
 	right := r.Eval(node.Right, scope)
+	if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+		return right
+	}
 
 	switch node.Operator {
 	case "+":
@@ -318,12 +545,12 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 	switch node.Operator {
 	case "+":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 
 		right := r.Eval(node.Right, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return right
 		}
 
@@ -331,12 +558,12 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 
 	case "-":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 
 		right := r.Eval(node.Right, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return right
 		}
 
@@ -344,12 +571,12 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 
 	case "*":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 
 		right := r.Eval(node.Right, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return right
 		}
 
@@ -357,12 +584,12 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 
 	case "/":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 
 		right := r.Eval(node.Right, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return right
 		}
 
@@ -370,12 +597,12 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 
 	case "//":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 
 		right := r.Eval(node.Right, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return right
 		}
 
@@ -383,12 +610,12 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 
 	case "%":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 
 		right := r.Eval(node.Right, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return right
 		}
 
@@ -396,12 +623,12 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 
 	case "**":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 
 		right := r.Eval(node.Right, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return right
 		}
 
@@ -409,12 +636,12 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 
 	case "==":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 
 		right := r.Eval(node.Right, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return right
 		}
 
@@ -422,12 +649,12 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 
 	case "!=":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 
 		right := r.Eval(node.Right, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return right
 		}
 
@@ -435,12 +662,12 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 
 	case ">":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 
 		right := r.Eval(node.Right, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return right
 		}
 
@@ -448,12 +675,12 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 
 	case "<":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 
 		right := r.Eval(node.Right, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return right
 		}
 
@@ -461,12 +688,12 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 
 	case ">=":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 
 		right := r.Eval(node.Right, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return right
 		}
 
@@ -474,12 +701,12 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 
 	case "<=":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 
 		right := r.Eval(node.Right, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return right
 		}
 
@@ -487,12 +714,12 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 
 	case "and", "or", "nand", "nor", "xor", "nxor":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 
 		right := r.Eval(node.Right, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return right
 		}
 
@@ -517,12 +744,12 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 
 	case "..":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 
 		right := r.Eval(node.Right, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return right
 		}
 
@@ -532,7 +759,7 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 
 	case "??":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 
@@ -554,7 +781,7 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 
 	case "as":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 
@@ -568,12 +795,12 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 
 	case "is":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 
 		right := r.Eval(node.Right, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return right
 		}
 
@@ -581,12 +808,12 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 
 	case "in":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 
 		right := r.Eval(node.Right, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return right
 		}
 
@@ -594,13 +821,13 @@ func (r *Runtime) EvalBinaryOperator(node *ast.BinaryOperator, scope *Scope) *In
 
 	case "to":
 		left := r.Eval(node.Left, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return left
 		}
 		iter := left.OnIter(r, scope)
 
 		right := r.Eval(node.Right, scope)
-		if scope.IsInterruptedAs(FlowRaise) {
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return right
 		}
 
@@ -618,12 +845,33 @@ func (r *Runtime) EvalPostfixOperator(node *ast.PostfixOperator, scope *Scope) *
 func (r *Runtime) ResolveAssignment(left ast.Node, right *Instance, assignment *ast.Assignment, scope *Scope) *Instance {
 	switch id := left.(type) {
 	case *ast.Tuple:
+		if id.Names != nil {
+			return r.Throw(Error.Create(scope, "cannot assign to a named tuple, use '{...}' to destructure by name"), scope)
+		}
+
 		if len(id.Values) == 1 {
 			return r.ResolveAssignment(id.Values[0], right, assignment, scope)
 		}
 
+		// lazy sequences are consumed before being destructured
+		if right.IsIterator() || right.IsSet() {
+			values, err := collect(r, scope, right)
+			if err != nil {
+				return r.Throw(err, scope)
+			}
+			right = List.Create(values...)
+		}
+
+		if !isSequence(right) {
+			return r.Throw(Error.Create(scope, "cannot destructure a value of type '%s' into the pattern %s", right.Type.GetName(), patternOf(id)), scope)
+		}
+
 		leftLength := len(id.Values)
-		rightLength := AsNumber(right.OnLen(r, scope))
+		length := right.OnLen(r, scope)
+		if scope.IsInterruptedAs(FlowRaise) {
+			return length
+		}
+		rightLength := AsNumber(length)
 
 		j := 0
 		for i, lv := range id.Values {
@@ -641,6 +889,9 @@ func (r *Runtime) ResolveAssignment(left ast.Node, right *Instance, assignment *
 
 				rv := List.Create(spreadItems...)
 				r.ResolveAssignment(lvspread.Target, rv, assignment, scope)
+				if scope.IsInterruptedAs(FlowRaise) {
+					return rv
+				}
 
 			} else {
 				if j >= int(rightLength) {
@@ -649,6 +900,9 @@ func (r *Runtime) ResolveAssignment(left ast.Node, right *Instance, assignment *
 
 				rv := right.OnGetItem(r, scope, Number.Create(float64(j)))
 				r.ResolveAssignment(lv, rv, assignment, scope)
+				if scope.IsInterruptedAs(FlowRaise) {
+					return rv
+				}
 				j++
 			}
 		}
@@ -659,6 +913,9 @@ func (r *Runtime) ResolveAssignment(left ast.Node, right *Instance, assignment *
 
 		return right
 
+	case *ast.FieldPattern:
+		return r.ResolveFieldPattern(id, right, assignment, scope)
+
 	case *ast.Identifier:
 		return r.Assign(id.Value, right, assignment.Definition, assignment.Constant, scope)
 
@@ -689,8 +946,117 @@ func (r *Runtime) ResolveAssignment(left ast.Node, right *Instance, assignment *
 	}
 }
 
+// Reports if the value can be destructured by position
+func isSequence(value *Instance) bool {
+	if value.IsCustom() {
+		custom := value.Type.(*CustomType)
+		return custom.MetaFunctions[string(meta.Len)] != nil && custom.MetaFunctions[string(meta.GetItem)] != nil
+	}
+
+	return value.IsList() || value.IsTuple() || value.IsString()
+}
+
+// Describes the destructuring pattern as written, such as `(a, (b, c))`
+func patternOf(node ast.Node) string {
+	switch n := node.(type) {
+	case *ast.Identifier:
+		return n.Value
+
+	case *ast.SpreadIn:
+		return "..." + patternOf(n.Target)
+
+	case *ast.Tuple:
+		values := []string{}
+		for _, v := range n.Values {
+			values = append(values, patternOf(v))
+		}
+		return "(" + strings.Join(values, ", ") + ")"
+
+	case *ast.FieldPattern:
+		fields := []string{}
+		for _, f := range n.Fields {
+			fields = append(fields, patternOf(f))
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+
+	default:
+		return node.String()
+	}
+}
+
+// Assigns the fields named in the pattern, reading dict keys or object
+// properties. A spread field receives the remaining keys of a dict, or the
+// remaining fields of a named tuple.
+func (r *Runtime) ResolveFieldPattern(pattern *ast.FieldPattern, right *Instance, assignment *ast.Assignment, scope *Scope) *Instance {
+	used := map[string]bool{}
+	for _, field := range pattern.Fields {
+		if spread, ok := field.(*ast.SpreadIn); ok {
+			var rest *Instance
+			switch {
+			case right.IsDict():
+				rest = Dict.Create(map[string]*Instance{})
+				for key, value := range right.AsDict().Values {
+					if !used[key] {
+						rest.AsDict().Values[key] = value
+						if original, has := right.AsDict().Keys[key]; has {
+							rest.AsDict().Keys[key] = original
+						}
+					}
+				}
+
+			case right.IsTuple() && right.AsTuple().Names != nil:
+				positions := []int{}
+				for i, name := range right.AsTuple().Names {
+					if !used[name] {
+						positions = append(positions, i)
+					}
+				}
+				rest = right.AsTuple().slice(positions)
+
+			default:
+				return r.Throw(Error.Create(scope, "cannot spread the fields of type '%s'", right.Type.GetName()), scope)
+			}
+
+			r.ResolveAssignment(spread.Target, rest, assignment, scope)
+			if scope.IsInterruptedAs(FlowRaise) {
+				return rest
+			}
+			continue
+		}
+
+		name := field.(*ast.Identifier).Value
+		used[name] = true
+
+		var value *Instance
+		if right.IsDict() {
+			v, has := right.AsDict().Values[name]
+			if !has {
+				return r.Throw(Error.Create(scope, "key '%s' not found in dict", name), scope)
+			}
+			value = v
+
+		} else {
+			value = right.OnGet(r, scope, String.Create(name))
+			if scope.IsInterruptedAs(FlowRaise) {
+				return value
+			}
+		}
+
+		r.Assign(name, value, assignment.Definition, assignment.Constant, scope)
+		if scope.IsInterruptedAs(FlowRaise) {
+			return value
+		}
+	}
+
+	return right
+}
+
 func (r *Runtime) EvalAssignment(node *ast.Assignment, scope *Scope) *Instance {
 	right := r.Eval(node.Expression, scope)
+	if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+		return right
+	}
+
 	return r.ResolveAssignment(node.Identifier, right, node, scope)
 }
 
@@ -757,6 +1123,7 @@ func (r *Runtime) EvalFunctionDef(node *ast.FunctionDef, scope *Scope) *Instance
 			Name:    param.Name,
 			Spread:  param.Spread,
 			Default: nil,
+			Pattern: param.Pattern,
 		}
 
 		if param.Default != nil {
@@ -798,6 +1165,26 @@ func (r *Runtime) EvalFunctionDef(node *ast.FunctionDef, scope *Scope) *Instance
 	impl := fn.Impl.(*FunctionDataImpl)
 	impl.Generator = node.Generator
 
+	for i := len(node.Decorators) - 1; i >= 0; i-- {
+		decorator := r.Eval(node.Decorators[i], scope)
+		if scope.IsInterruptedAs(FlowRaise) {
+			return decorator
+		}
+
+		if !decorator.IsCallable() {
+			return r.Throw(Error.Create(scope, "decorator must be callable, '%s' given", decorator.Type.GetName()), scope)
+		}
+
+		fn = r.callValue(decorator, nil, []*Instance{fn}, scope)
+		if scope.IsInterruptedAs(FlowRaise) {
+			return fn
+		}
+
+		if !fn.IsCallable() {
+			return r.Throw(Error.Create(scope, "decorator must return a callable, '%s' returned", fn.Type.GetName()), scope)
+		}
+	}
+
 	if !scope.InAssignment && !scope.InArgument && name != "" {
 		scope.Set(name, fn)
 	}
@@ -806,7 +1193,19 @@ func (r *Runtime) EvalFunctionDef(node *ast.FunctionDef, scope *Scope) *Instance
 }
 
 func (r *Runtime) EvalCall(node *ast.Call, scope *Scope) *Instance {
+	return r.evalCall(node, scope)
+}
+
+// Evaluates the call, passing the prefix values before the call arguments
+func (r *Runtime) evalCall(node *ast.Call, scope *Scope, prefix ...*Instance) *Instance {
 	target := r.Eval(node.Target, scope)
+	if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+		return target
+	}
+
+	if len(prefix) > 0 && !target.IsCallable() {
+		return r.Throw(Error.Create(scope, "cannot forward value to non-callable type '%s'", target.Type.GetName()), scope)
+	}
 
 	isType := target.Type == Type.Type
 	if !isType && node.Initializer != nil {
@@ -817,10 +1216,14 @@ func (r *Runtime) EvalCall(node *ast.Call, scope *Scope) *Instance {
 	if target.MemberOf != nil && target.MemberOf.Type != Module.Type {
 		args = append(args, target.MemberOf)
 	}
+	args = append(args, prefix...)
 	for _, v := range node.Arguments {
 		if spread, ok := v.(*ast.SpreadOut); ok {
 			var e *Instance
 			target := r.Eval(spread.Target, scope)
+			if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+				return target
+			}
 			r.ResolveIterator(target, scope, func(v *Instance, err *Instance) {
 				if err != nil {
 					e = err
@@ -835,12 +1238,51 @@ func (r *Runtime) EvalCall(node *ast.Call, scope *Scope) *Instance {
 			continue
 		}
 
-		args = append(args, r.Eval(v, scope))
+		if !scope.InMatchCase && isHoleNode(v) {
+			if kw, ok := v.(*ast.KeywordArgument); ok {
+				args = append(args, KeywordArgument.Create(kw.Name, WildCard.UNDERSCORE))
+			} else {
+				args = append(args, WildCard.UNDERSCORE)
+			}
+			continue
+		}
+
+		arg := r.Eval(v, scope)
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+			return arg
+		}
+		args = append(args, arg)
+	}
+
+	call := func(r *Runtime, scope *Scope, args []*Instance) *Instance {
+		return r.callValue(target, node.Initializer, args, scope)
+	}
+
+	for _, arg := range args {
+		if isHole(arg) {
+			return createPartial(target, call, args)
+		}
 	}
 
-	if isType {
+	return call(r, scope, args)
+}
+
+// Calls the target, instantiating it when it is a type
+func (r *Runtime) callValue(target *Instance, init ast.Initializer, args []*Instance, scope *Scope) *Instance {
+	if target.Type == Type.Type {
 		impl := target.Impl.(*TypeDataImpl)
-		value := impl.DataType.Instantiate(r, scope, node.Initializer)
+		// only data types with `on new` receive the keyword arguments
+		custom, ok := impl.DataType.(*CustomType)
+		acceptsKeywords := ok && custom.MetaFunctions[string(meta.New)] != nil
+		if !acceptsKeywords && hasKeywordArguments(args) {
+			return r.Throw(Error.Create(scope, "type '%s' does not accept keyword arguments", impl.DataType.GetName()), scope)
+		}
+
+		value := impl.DataType.Instantiate(r, scope, init)
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+			return value
+		}
+
 		return value.OnNew(r, scope, args...)
 
 	} else {
@@ -848,58 +1290,263 @@ func (r *Runtime) EvalCall(node *ast.Call, scope *Scope) *Instance {
 	}
 }
 
-func (r *Runtime) EvalContinue(node *ast.Continue, scope *Scope) *Instance {
-	return scope.Interrupt(FlowContinue, Boolean.TRUE)
-}
-
-func (r *Runtime) EvalBreak(node *ast.Break, scope *Scope) *Instance {
-	return scope.Interrupt(FlowBreak, Boolean.TRUE)
-}
+func (r *Runtime) EvalForward(node *ast.Forward, scope *Scope) *Instance {
+	// the left side is a complete expression, so its pipes are collected
+	counter := scope.PipeCounter
+	scope.PipeCounter = 0
+	left := r.Eval(node.Left, scope)
+	scope.PipeCounter = counter
+	if scope.IsInterruptedAs(FlowRaise) {
+		return left
+	}
 
-func (r *Runtime) EvalReturn(node *ast.Return, scope *Scope) *Instance {
-	exp := r.Eval(node.Expression, scope)
-	if exp == nil {
-		exp = Boolean.FALSE
+	args := []*Instance{left}
+	if node.ArgFn != nil {
+		argFn := r.Eval(node.ArgFn, scope)
+		argFn.AsFunction().Piped = true
+		if node.Target == nil {
+			return argFn.OnCall(r, scope, left)
+		}
+		args = append(args, argFn)
 	}
 
-	return scope.Interrupt(FlowReturn, exp)
-}
+	if call, ok := node.Target.(*ast.Call); ok && !hasHoleNodes(call) {
+		return r.evalCall(call, scope, args...)
+	}
 
-func (r *Runtime) EvalRaise(node *ast.Raise, scope *Scope) *Instance {
-	exp := r.Eval(node.Expression, scope)
-	if exp == nil {
-		exp = Boolean.FALSE
+	target := r.Eval(node.Target, scope)
+	if scope.IsInterruptedAs(FlowRaise) {
+		return target
 	}
 
-	if exp.Type == Error.Type {
-		return r.Throw(exp, scope)
-	} else {
-		return r.Throw(Error.Create(scope,
-			AsString(exp.OnString(r, scope)),
-		), scope)
+	if !target.IsCallable() {
+		return r.Throw(Error.Create(scope, "cannot forward value to non-callable type '%s'", target.Type.GetName()), scope)
 	}
+
+	return r.callValue(target, nil, args, scope)
 }
 
-func (r *Runtime) EvalYield(node *ast.Yield, scope *Scope) *Instance {
-	exp := r.Eval(node.Expression, scope)
-	if exp == nil {
-		exp = Boolean.FALSE
+// Reports if any argument of the call is a `_` placeholder
+func hasHoleNodes(node *ast.Call) bool {
+	for _, arg := range node.Arguments {
+		if isHoleNode(arg) {
+			return true
+		}
 	}
 
-	return scope.Interrupt(FlowYield, exp)
+	return false
 }
 
-func (r *Runtime) EvalIndexing(node *ast.Indexing, scope *Scope) *Instance {
-	target := r.Eval(node.Target, scope)
+// Reports if the argument node is a `_` or `name=_` placeholder
+func isHoleNode(node ast.Node) bool {
+	if kw, ok := node.(*ast.KeywordArgument); ok {
+		node = kw.Value
+	}
+
+	id, ok := node.(*ast.Identifier)
+	return ok && id.Value == "_"
+}
+
+func (r *Runtime) EvalContinue(node *ast.Continue, scope *Scope) *Instance {
+	scope.Interrupt(FlowContinue, Boolean.TRUE)
+	scope.Interruption.Label = node.Label
+	return Boolean.TRUE
+}
+
+func (r *Runtime) EvalBreak(node *ast.Break, scope *Scope) *Instance {
+	value := Boolean.FALSE
+	if node.Value != nil {
+		value = r.Eval(node.Value, scope)
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+			return value
+		}
+	}
+
+	scope.Interrupt(FlowBreak, value)
+	scope.Interruption.Label = node.Label
+	return value
+}
+
+// Reports if the loop scope was interrupted by a break or continue targeting
+// the loop, which is any unlabeled one or the one using the loop label
+func isLoopFlow(scope *Scope, flow FlowType, label string) bool {
+	if !scope.IsInterruptedAs(flow) {
+		return false
+	}
+
+	return scope.Interruption.Label == "" || scope.Interruption.Label == label
+}
+
+// Evaluates the else branch of a loop that finished without breaking. The
+// record is used to resume the branch when it yields.
+func (r *Runtime) evalLoopElse(body ast.Node, loopScope *Scope, scope *Scope, record ExecutionRecord) *Instance {
+	if body == nil {
+		return Boolean.FALSE
+	}
+
+	ret := r.Eval(body, loopScope)
+	if loopScope.IsInterruptedAs(FlowYield) {
+		scope.ActiveRecord = record
+	}
+
+	if loopScope.Interruption != nil {
+		return loopScope.Propagate()
+	}
+
+	return ret
+}
+
+func (r *Runtime) EvalReturn(node *ast.Return, scope *Scope) *Instance {
+	exp := r.Eval(node.Expression, scope)
+	if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+		return exp
+	}
+	if exp == nil {
+		exp = Boolean.FALSE
+	}
+
+	return scope.Interrupt(FlowReturn, exp)
+}
+
+func (r *Runtime) EvalRaise(node *ast.Raise, scope *Scope) *Instance {
+	exp := r.Eval(node.Expression, scope)
+	if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+		return exp
+	}
+	if exp == nil {
+		exp = Boolean.FALSE
+	}
+
+	if exp.Type == Error.Type {
+		return r.Throw(exp, scope)
+	} else {
+		return r.Throw(Error.Create(scope,
+			AsString(exp.OnString(r, scope)),
+		), scope)
+	}
+}
+
+func (r *Runtime) EvalYield(node *ast.Yield, scope *Scope) *Instance {
+	gen := scope.Generator
+	if gen == nil {
+		return r.Throw(Error.Create(scope, "yield used outside of a generator"), scope)
+	}
+
+	// resuming the suspended yield
+	if gen.Pending == node {
+		gen.Pending = nil
+		sent := gen.Sent
+		gen.Sent = nil
+
+		if gen.Closing {
+			if gen.Delegate != nil {
+				Iterator_Close.OnCall(r, scope, gen.Delegate)
+				gen.Delegate = nil
+			}
+			return scope.Interrupt(FlowReturn, Boolean.FALSE)
+		}
+
+		if node.From {
+			return r.delegateYield(node, sent, scope)
+		}
+
+		if sent == nil {
+			return Boolean.FALSE
+		}
+		return sent
+	}
+
+	exp := r.Eval(node.Expression, scope)
+	if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+		return exp
+	}
+
+	if node.From {
+		if !exp.IsIterator() {
+			exp = exp.OnIter(r, scope)
+			if scope.IsInterruptedAs(FlowRaise) {
+				return exp
+			}
+		}
+
+		gen.Delegate = exp
+		return r.delegateYield(node, nil, scope)
+	}
+
+	gen.Pending = node
+	return scope.Interrupt(FlowYield, exp)
+}
+
+// Advances the iterator of a `yield from`, yielding its values until it is
+// done, when the expression results in the iterator's return value
+func (r *Runtime) delegateYield(node *ast.Yield, sent *Instance, scope *Scope) *Instance {
+	gen := scope.Generator
+	delegate := gen.Delegate
+
+	var ret *Instance
+	if sent != nil && delegate.AsIterator().Generator != nil {
+		ret = Iterator_Send.OnCall(r, scope, delegate, sent)
+	} else {
+		ret = Iterator_Next.OnCall(r, scope, delegate)
+	}
+	if scope.IsInterruptedAs(FlowRaise) {
+		gen.Delegate = nil
+		return ret
+	}
+
+	iteration := ret.AsIteration()
+	if AsBool(iteration.error()) {
+		gen.Delegate = nil
+		return r.Throw(iteration.value().AsTuple().Values[0], scope)
+
+	} else if AsBool(iteration.done()) {
+		gen.Delegate = nil
+		return delegate.AsIterator().result()
+	}
+
+	gen.Pending = node
+	gen.delegated = true
+	return scope.Interrupt(FlowYield, iteration.value())
+}
+
+func (r *Runtime) EvalIndexing(node *ast.Indexing, scope *Scope) *Instance {
+	target := r.Eval(node.Target, scope)
+	if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+		return target
+	}
 
 	args := make([]*Instance, len(node.Values))
 	for i, v := range node.Values {
 		args[i] = r.Eval(v, scope)
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+			return args[i]
+		}
 	}
 
 	return target.OnGetItem(r, scope, args...)
 }
 
+func (r *Runtime) EvalSlice(node *ast.Slice, scope *Scope) *Instance {
+	parts := make([]*Instance, 3)
+	for i, v := range []ast.Node{node.Start, node.Stop, node.Step} {
+		parts[i] = r.Eval(v, scope)
+		if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+			return parts[i]
+		}
+	}
+
+	return Slice.Create(parts[0], parts[1], parts[2])
+}
+
+func (r *Runtime) EvalKeywordArgument(node *ast.KeywordArgument, scope *Scope) *Instance {
+	value := r.Eval(node.Value, scope)
+	if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+		return value
+	}
+
+	return KeywordArgument.Create(node.Name, value)
+}
+
 func (r *Runtime) EvalWrapping(node *ast.Wrapping, scope *Scope) *Instance {
 	exp := r.Eval(node.Expression, scope)
 
@@ -921,6 +1568,42 @@ func (r *Runtime) EvalUnwrap(node *ast.Unwrapping, scope *Scope) *Instance {
 	return r.SolveMaybe(target, scope)
 }
 
+// Evaluates `try expr`. A raised error, an error Maybe or an Error value
+// returns a Maybe with the error from the enclosing function, otherwise the
+// expression results in the (unwrapped) value
+func (r *Runtime) EvalTry(node *ast.Try, scope *Scope) *Instance {
+	exp := r.Eval(node.Expression, scope)
+	if scope.IsInterruptedAs(FlowYield) {
+		return exp
+	}
+
+	var err *Instance
+	if scope.IsInterruptedAs(FlowRaise) {
+		err = scope.Interruption.Value
+		scope.Interruption = nil
+
+	} else if exp.IsMaybe() {
+		maybe := exp.AsMaybe()
+		if maybe.Error == nil {
+			return maybe.Value
+		}
+		err = maybe.Error
+
+	} else if exp.IsError() {
+		err = exp
+
+	} else {
+		return exp
+	}
+
+	// there is no function to return from, so the error is raised
+	if scope.Function == nil {
+		return r.Throw(err, scope)
+	}
+
+	return scope.Interrupt(FlowReturn, Maybe.CreateError(err))
+}
+
 func (r *Runtime) SolveMaybe(target *Instance, scope *Scope) *Instance {
 	if target.Type != Maybe.Type {
 		return r.Throw(Error.Create(scope, "cannot unwrap non-maybe type"), scope)
@@ -957,6 +1640,9 @@ func (r *Runtime) EvalIf(node *ast.If, scope *Scope) *Instance {
 		if c == nil {
 			return r.Throw(Error.Create(scope, "invalid condition"), scope)
 		}
+		if newScope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+			return newScope.Propagate()
+		}
 
 		t := true
 		f := false
@@ -992,9 +1678,11 @@ func (r *Runtime) EvalIf(node *ast.If, scope *Scope) *Instance {
 func (r *Runtime) EvalFor(node *ast.For, scope *Scope) *Instance {
 	var newScope *Scope
 	var evalCondition bool
+	inElse := false
 	if scope.ActiveRecord != nil {
 		state := scope.ActiveRecord.(*ForRecord)
 		newScope = state.Scope
+		inElse = state.Else
 		evalCondition = false
 	} else {
 		newScope = CreateScope(scope, scope.Caller, scope)
@@ -1003,7 +1691,7 @@ func (r *Runtime) EvalFor(node *ast.For, scope *Scope) *Instance {
 	}
 	scope.ActiveRecord = nil
 
-	for {
+	for !inElse {
 		if evalCondition {
 			newScope.Clear()
 
@@ -1011,6 +1699,9 @@ func (r *Runtime) EvalFor(node *ast.For, scope *Scope) *Instance {
 			if c == nil {
 				return r.Throw(Error.Create(scope, "invalid condition"), scope)
 			}
+			if newScope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+				return newScope.Propagate()
+			}
 
 			if !AsBool(c) {
 				break
@@ -1020,15 +1711,17 @@ func (r *Runtime) EvalFor(node *ast.For, scope *Scope) *Instance {
 		evalCondition = true
 		r.Eval(node.Body, newScope)
 
-		if newScope.IsInterruptedAs(FlowBreak) {
+		if isLoopFlow(newScope, FlowBreak, node.Label) {
+			value := newScope.Interruption.Value
 			newScope.Interruption = nil
-			break
-		} else if newScope.IsInterruptedAs(FlowContinue) {
+			return value
+
+		} else if isLoopFlow(newScope, FlowContinue, node.Label) {
 			newScope.Interruption = nil
 			continue
-		} else if newScope.IsInterruptedAs(FlowReturn, FlowRaise) {
-			newScope.Propagate()
-			break
+
+		} else if newScope.IsInterruptedAs(FlowBreak, FlowContinue, FlowReturn, FlowRaise) {
+			return newScope.Propagate()
 
 		} else if newScope.IsInterruptedAs(FlowYield) {
 			scope.ActiveRecord = &ForRecord{
@@ -1038,7 +1731,10 @@ func (r *Runtime) EvalFor(node *ast.For, scope *Scope) *Instance {
 		}
 	}
 
-	return Boolean.FALSE
+	return r.evalLoopElse(node.Else, newScope, scope, &ForRecord{
+		Scope: newScope,
+		Else:  true,
+	})
 }
 
 func (r *Runtime) EvalSpreadOut(node *ast.SpreadOut, scope *Scope) *Instance {
@@ -1062,6 +1758,131 @@ func (r *Runtime) EvalSpreadOut(node *ast.SpreadOut, scope *Scope) *Instance {
 	return Tuple.Create(values...)
 }
 
+// EvalComprehension creates a lazy iterator over the values of a
+// comprehension. Every `for` clause binds its targets in a scope nested in the
+// scope of the previous clause, so inner clauses can use the outer bindings.
+// After each value, the search resumes by advancing the innermost clause.
+func (r *Runtime) EvalComprehension(node *ast.Comprehension, scope *Scope) *Instance {
+	n := len(node.Clauses)
+	scopes := make([]*Scope, n+1)
+	iterators := make([]*Instance, n)
+	scopes[0] = CreateScope(scope, scope.Caller, nil)
+	scopes[0].Name = "comprehension"
+
+	started := false
+	finished := false
+	fail := func(s *Scope) *Instance {
+		finished = true
+		return Iteration.Error(s.Interruption.Value)
+	}
+
+	return i(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		if finished {
+			return Iteration.DONE
+		}
+
+		pos, forward := 0, true
+		if started {
+			pos, forward = n-1, false
+		}
+		started = true
+
+		for pos >= 0 {
+			if pos == n {
+				inner := scopes[n]
+				value := r.Eval(node.Value, inner)
+				if inner.IsInterruptedAs(FlowRaise) {
+					return fail(inner)
+				}
+
+				if node.Key == nil {
+					return Iteration.Create(value)
+				}
+
+				key := r.Eval(node.Key, inner)
+				if inner.IsInterruptedAs(FlowRaise) {
+					return fail(inner)
+				}
+				return Iteration.Create(key, value)
+			}
+
+			clause := node.Clauses[pos]
+			cur := scopes[pos]
+
+			if clause.IsFilter() {
+				if forward {
+					condition := r.Eval(clause.Condition, cur)
+					if cur.IsInterruptedAs(FlowRaise) {
+						return fail(cur)
+					}
+
+					if AsBool(condition) {
+						scopes[pos+1] = cur
+						pos++
+						continue
+					}
+				}
+
+				forward = false
+				pos--
+				continue
+			}
+
+			if forward {
+				iterable := r.Eval(clause.Iterable, cur)
+				if cur.IsInterruptedAs(FlowRaise) {
+					return fail(cur)
+				}
+
+				iter, err := iterOf(r, cur, iterable)
+				if err != nil {
+					return fail(cur)
+				}
+				iterators[pos] = iter
+			}
+
+			ret := advance(r, cur, iterators[pos])
+			if cur.IsInterruptedAs(FlowRaise) {
+				return fail(cur)
+			}
+
+			iteration := ret.AsIteration()
+			if AsBool(iteration.error()) {
+				finished = true
+				return ret
+
+			} else if AsBool(iteration.done()) {
+				forward = false
+				pos--
+				continue
+			}
+
+			// iterations with several values are destructured as a whole
+			values := iteration.value().AsTuple().Values
+			right := values[0]
+			if _, isTuple := clause.Target.(*ast.Tuple); isTuple && len(values) > 1 {
+				right = Tuple.Create(values...)
+			}
+
+			next := CreateScope(cur, cur.Caller, nil)
+			r.ResolveAssignment(clause.Target, right, &ast.Assignment{
+				Definition: true,
+				Constant:   false,
+			}, next)
+			if next.IsInterruptedAs(FlowRaise) {
+				return fail(next)
+			}
+
+			scopes[pos+1] = next
+			forward = true
+			pos++
+		}
+
+		finished = true
+		return Iteration.DONE
+	})
+}
+
 func (r *Runtime) ResolveIterator(target *Instance, scope *Scope, up func(*Instance, *Instance)) {
 	iter := target.OnIter(r, scope)
 	if iter.Type != Iterator.Type {
@@ -1100,10 +1921,17 @@ func (r *Runtime) ResolveIterator(target *Instance, scope *Scope, up func(*Insta
 
 func (r *Runtime) EvalAccess(node *ast.Access, scope *Scope) *Instance {
 	left := r.Eval(node.Left, scope)
+	if scope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+		return left
+	}
 	right := node.Right.(*ast.Identifier).Value
 
 	res := left.OnGet(r, scope, String.Create(right))
 	res.MemberOf = left
+	if left.IsSuper() {
+		// parent implementations are still called with the current instance
+		res.MemberOf = left.AsSuper().This
+	}
 	return res
 }
 
@@ -1168,7 +1996,9 @@ func (r *Runtime) EvalPipe(node *ast.Pipe, scope *Scope) *Instance {
 		if argFn.Type != Function.Type {
 			return r.Throw(Error.Create(scope, "cannot use non-function as argument function"), scope)
 		}
-		argFn.Impl.(*FunctionDataImpl).Piped = true
+		if _, ok := node.ArgFn.(*ast.FunctionDef); ok {
+			argFn.Impl.(*FunctionDataImpl).Piped = true
+		}
 	} else {
 		argFn = Boolean.FALSE
 	}
@@ -1214,10 +2044,12 @@ func (r *Runtime) EvalPipeLoop(node *ast.PipeLoop, scope *Scope) *Instance {
 	var newScope *Scope
 	var evalCondition bool
 	var i_iterator *Instance
+	inElse := false
 	if scope.ActiveRecord != nil {
 		state := scope.ActiveRecord.(*PipeLoopRecord)
 		newScope = state.Scope
 		i_iterator = state.Iterator
+		inElse = state.Else
 		evalCondition = false
 	} else {
 		newScope = CreateScope(scope, scope.Caller, scope)
@@ -1244,7 +2076,7 @@ func (r *Runtime) EvalPipeLoop(node *ast.PipeLoop, scope *Scope) *Instance {
 	}
 
 	iterator := i_iterator.Impl.(*IteratorDataImpl)
-	for {
+	for !inElse {
 		if evalCondition {
 			newScope.Clear()
 
@@ -1252,40 +2084,45 @@ func (r *Runtime) EvalPipeLoop(node *ast.PipeLoop, scope *Scope) *Instance {
 			i_iteration := i_next.OnCall(r, scope, i_iterator)
 			iteration := i_iteration.Impl.(*IterationDataImpl)
 
-			if iteration.done() == Boolean.TRUE || iteration.error() == Boolean.TRUE {
+			if iteration.error() == Boolean.TRUE {
+				return Boolean.FALSE
+
+			} else if iteration.done() == Boolean.TRUE {
 				break
 			}
 
 			i_value := iteration.value()
 			value := i_value.Impl.(*TupleDataImpl)
 
-			asTuple, isTuple := node.Assignment.(*ast.Tuple)
-			if !isTuple {
-				asTuple = &ast.Tuple{
-					Values: []ast.Node{node.Assignment},
-				}
-			} else {
-				r.Throw(Error.Create(scope, "tuple assignment not implement yet"), scope)
+			// iterations with several values are destructured as a whole
+			right := value.Values[0]
+			if _, isTuple := node.Assignment.(*ast.Tuple); isTuple && len(value.Values) > 1 {
+				right = Tuple.Create(value.Values...)
 			}
 
-			r.ResolveAssignment(asTuple, value.Values[0], &ast.Assignment{
+			r.ResolveAssignment(node.Assignment, right, &ast.Assignment{
 				Definition: true,
 				Constant:   false,
 			}, newScope)
+			if newScope.IsInterruptedAs(FlowRaise) {
+				return r.Throw(newScope.Interruption.Value, scope)
+			}
 		}
 		evalCondition = true
 		r.Eval(node.Body, newScope)
 
 		// execute block, if return evalCondition = true
-		if newScope.IsInterruptedAs(FlowBreak) {
+		if isLoopFlow(newScope, FlowBreak, node.Label) {
+			value := newScope.Interruption.Value
 			newScope.Interruption = nil
-			break
-		} else if newScope.IsInterruptedAs(FlowContinue) {
+			return value
+
+		} else if isLoopFlow(newScope, FlowContinue, node.Label) {
 			newScope.Interruption = nil
 			continue
-		} else if newScope.IsInterruptedAs(FlowReturn, FlowRaise) {
-			newScope.Propagate()
-			break
+
+		} else if newScope.IsInterruptedAs(FlowBreak, FlowContinue, FlowReturn, FlowRaise) {
+			return newScope.Propagate()
 
 		} else if newScope.IsInterruptedAs(FlowYield) {
 			scope.ActiveRecord = &PipeLoopRecord{
@@ -1296,7 +2133,11 @@ func (r *Runtime) EvalPipeLoop(node *ast.PipeLoop, scope *Scope) *Instance {
 		}
 	}
 
-	return Boolean.FALSE
+	return r.evalLoopElse(node.Else, newScope, scope, &PipeLoopRecord{
+		Scope:    newScope,
+		Iterator: i_iterator,
+		Else:     true,
+	})
 }
 
 func (r *Runtime) EvalDataDef(node *ast.DataDef, scope *Scope) *Instance {
@@ -1311,30 +2152,63 @@ func (r *Runtime) EvalDataDef(node *ast.DataDef, scope *Scope) *Instance {
 	instanceFns := map[string]*Instance{}
 	staticFns := map[string]*Instance{}
 	metaFns := map[string]*Instance{}
-
+	declared := &CustomMembers{
+		Properties:    map[string]ast.Node{},
+		StaticFns:     map[string]*Instance{},
+		InstanceFns:   map[string]*Instance{},
+		MetaFunctions: map[string]*Instance{},
+		Validators:    map[string]*PropertyValidator{},
+		Computed:      map[string]*Instance{},
+	}
+	validators := map[string]*PropertyValidator{}
+	computed := map[string]*Instance{}
+
+	bases := []*CustomType{}
 	for _, like := range node.Likes {
 		i_like, ok := scope.Get(like)
 		if !ok {
 			return r.Throw(Error.Create(scope, "cannot find type '%s'", like), scope)
 		}
 
+		if !i_like.IsType() {
+			return r.Throw(Error.Create(scope, "type '%s' is not custom data", like), scope)
+		}
+
 		t_like, ok := i_like.AsType().DataType.(*CustomType)
-		if !i_like.IsType() || !ok {
+		if !ok {
 			return r.Throw(Error.Create(scope, "type '%s' is not custom data", like), scope)
 		}
 
-		for k, v := range t_like.GetProperties() {
+		bases = append(bases, t_like)
+	}
+
+	mro, ok := linearize(bases)
+	if !ok {
+		return r.Throw(Error.Create(scope, "cannot create a consistent resolution order for type '%s'", name), scope)
+	}
+
+	// inherited members are copied from the least to the most specific type,
+	// so the types coming first in the resolution order win
+	for i := len(mro) - 1; i >= 0; i-- {
+		base := mro[i].Declared
+		for k, v := range base.Properties {
 			properties[k] = v
 		}
-		for k, v := range t_like.GetStaticFns() {
+		for k, v := range base.StaticFns {
 			staticFns[k] = v
 		}
-		for k, v := range t_like.GetInstanceFns() {
+		for k, v := range base.InstanceFns {
 			instanceFns[k] = v
 		}
-		for k, v := range t_like.MetaFunctions {
+		for k, v := range base.MetaFunctions {
 			metaFns[k] = v
 		}
+		for k, v := range base.Validators {
+			validators[k] = v
+		}
+		for k, v := range base.Computed {
+			computed[k] = v
+		}
 	}
 
 	for _, v := range node.Properties {
@@ -1345,6 +2219,24 @@ func (r *Runtime) EvalDataDef(node *ast.DataDef, scope *Scope) *Instance {
 
 		names[prop.Name] = true
 		properties[prop.Name] = prop.Value
+		declared.Properties[prop.Name] = prop.Value
+		if prop.Where != nil {
+			validators[prop.Name] = &PropertyValidator{Condition: prop.Where, Scope: scope}
+			declared.Validators[prop.Name] = validators[prop.Name]
+		}
+	}
+
+	for _, v := range node.Computed {
+		fn := v.(*ast.FunctionDef)
+		if names[fn.Name] {
+			return r.Throw(Error.DuplicatedDefinition(scope, fn.Name), scope)
+		}
+
+		names[fn.Name] = true
+		scope.InAssignment = true
+		computed[fn.Name] = r.Eval(fn, scope)
+		declared.Computed[fn.Name] = computed[fn.Name]
+		scope.InAssignment = false
 	}
 
 	for _, v := range node.Functions {
@@ -1357,8 +2249,10 @@ func (r *Runtime) EvalDataDef(node *ast.DataDef, scope *Scope) *Instance {
 		scope.InAssignment = true
 		if len(fn.Params) > 0 && fn.Params[0].(*ast.Parameter).Name == "this" {
 			instanceFns[fn.Name] = r.Eval(fn, scope)
+			declared.InstanceFns[fn.Name] = instanceFns[fn.Name]
 		} else {
 			staticFns[fn.Name] = r.Eval(fn, scope)
+			declared.StaticFns[fn.Name] = staticFns[fn.Name]
 		}
 		scope.InAssignment = false
 	}
@@ -1372,11 +2266,37 @@ func (r *Runtime) EvalDataDef(node *ast.DataDef, scope *Scope) *Instance {
 		metaNames[fn.Name] = true
 		scope.InAssignment = true
 		metaFns[fn.Name] = r.Eval(fn, scope)
+		declared.MetaFunctions[fn.Name] = metaFns[fn.Name]
 		scope.InAssignment = false
 	}
 
 	dt := CreateCustomType(name, properties, staticFns, instanceFns, metaFns)
 
+	custom := dt.AsType().DataType.(*CustomType)
+	custom.Bases = bases
+	custom.Mro = append([]*CustomType{custom}, mro...)
+	custom.Declared = declared
+	custom.Validators = validators
+	custom.Computed = computed
+	for _, fns := range []map[string]*Instance{declared.StaticFns, declared.InstanceFns, declared.Computed, declared.MetaFunctions} {
+		for _, fn := range fns {
+			if fn.IsFunction() {
+				fn.AsFunction().Owner = custom
+			}
+		}
+	}
+
+	for _, name := range node.Protocols {
+		protocol, err := r.resolveProtocol(name, scope)
+		if err != nil {
+			return err
+		}
+
+		if missing := protocol.Missing(custom); len(missing) > 0 {
+			return r.Throw(Error.Create(scope, "type '%s' does not implement protocol '%s', missing %s", custom.Name, protocol.Name, describeMembers(missing)), scope)
+		}
+	}
+
 	if !scope.InAssignment && !scope.InArgument && name != "" {
 		scope.Set(name, Constant(dt))
 	}
@@ -1384,6 +2304,105 @@ func (r *Runtime) EvalDataDef(node *ast.DataDef, scope *Scope) *Instance {
 	return dt
 }
 
+func (r *Runtime) EvalProtocolDef(node *ast.ProtocolDef, scope *Scope) *Instance {
+	name := node.Name
+	if scope.HasInScope(name) {
+		return r.Throw(Error.DuplicatedDefinition(scope, name), scope)
+	}
+
+	bases := []*ProtocolType{}
+	for _, like := range node.Likes {
+		base, err := r.resolveProtocol(like, scope)
+		if err != nil {
+			return err
+		}
+		bases = append(bases, base)
+	}
+
+	names := map[string]bool{}
+	members := []*ProtocolMember{}
+	for _, fns := range [][]ast.Node{node.Functions, node.MetaFunctions} {
+		for _, v := range fns {
+			fn := v.(*ast.FunctionDef)
+			member := &ProtocolMember{
+				Name:     fn.Name,
+				Meta:     fn.Token.Literal == "on",
+				Instance: len(fn.Params) > 0 && fn.Params[0].(*ast.Parameter).Name == "this",
+			}
+
+			if names[member.String()] {
+				return r.Throw(Error.DuplicatedDefinition(scope, fn.Name), scope)
+			}
+			names[member.String()] = true
+			members = append(members, member)
+		}
+	}
+
+	pt := Type.Create(CreateProtocolType(name, bases, members))
+	scope.Set(name, Constant(pt))
+	return pt
+}
+
+func (r *Runtime) EvalEnumDef(node *ast.EnumDef, scope *Scope) *Instance {
+	name := node.Name
+	if scope.HasInScope(name) {
+		return r.Throw(Error.DuplicatedDefinition(scope, name), scope)
+	}
+
+	enum := CreateEnumType(name)
+	for _, v := range node.Variants {
+		if enum.Variant(v.Name) != nil {
+			return r.Throw(Error.DuplicatedDefinition(scope, v.Name), scope)
+		}
+
+		fields := map[string]bool{}
+		for _, field := range v.Fields {
+			if fields[field] {
+				return r.Throw(Error.DuplicatedDefinition(scope, field), scope)
+			}
+			fields[field] = true
+		}
+
+		enum.AddVariant(v.Name, v.Fields)
+	}
+
+	et := Type.Create(enum)
+	scope.Set(name, Constant(et))
+	return et
+}
+
+// Finds the protocol with the given name, raising if it is not a protocol
+func (r *Runtime) resolveProtocol(name string, scope *Scope) (*ProtocolType, *Instance) {
+	value, ok := scope.Get(name)
+	if !ok {
+		return nil, r.Throw(Error.Create(scope, "cannot find protocol '%s'", name), scope)
+	}
+
+	if value.IsType() {
+		if protocol, ok := value.AsType().DataType.(*ProtocolType); ok {
+			return protocol, nil
+		}
+	}
+
+	return nil, r.Throw(Error.Create(scope, "'%s' is not a protocol", name), scope)
+}
+
+// EvalSuper gives access to the parent implementations of the data function
+// being executed, following the resolution order of the instance type.
+func (r *Runtime) EvalSuper(node *ast.Super, scope *Scope) *Instance {
+	owner := ownerOf(scope)
+	if owner == nil {
+		return r.Throw(Error.Create(scope, "super can only be used inside data functions"), scope)
+	}
+
+	this, ok := scope.Get("this")
+	if !ok {
+		return r.Throw(Error.Create(scope, "super requires an instance, '%s' function has no 'this'", owner.Name), scope)
+	}
+
+	return Super.Create(this, owner)
+}
+
 func (r *Runtime) EvalMatch(node *ast.Match, scope *Scope) *Instance {
 	var newScope *Scope
 	current := -1
@@ -1402,16 +2421,40 @@ func (r *Runtime) EvalMatch(node *ast.Match, scope *Scope) *Instance {
 		if exp == nil {
 			return r.Throw(Error.Create(scope, "invalid match expression"), scope)
 		}
-		if newScope.IsInterruptedAs(FlowRaise) {
+		if newScope.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
 			return newScope.Propagate()
 		}
 
+		if exp.IsEnum() {
+			r.checkVariantsCovered(node, exp.Type.(*EnumType), newScope)
+		}
+
 		for i, v := range node.Cases {
 			caseNode := v.(*ast.MatchCase)
 			if r.isUnderscore(caseNode.Condition) {
 				current = i
 				break
 			}
+			// variants are matched by name, binding their values
+			if exp.IsEnum() {
+				if variant := r.patternVariant(caseNode.Condition, exp.Type.(*EnumType), newScope); variant != nil {
+					bindings := map[string]*Instance{}
+					matched := r.matchVariant(caseNode.Condition, variant, exp, bindings, newScope)
+					if newScope.IsInterruptedAs(FlowRaise) {
+						return newScope.Propagate()
+					}
+
+					if matched {
+						for name, value := range bindings {
+							newScope.Set(name, value)
+						}
+						current = i
+						break
+					}
+					continue
+				}
+			}
+
 			newScope.InMatchCase = true
 			condition := r.Eval(caseNode.Condition, newScope)
 			newScope.InMatchCase = false
@@ -1423,6 +2466,15 @@ func (r *Runtime) EvalMatch(node *ast.Match, scope *Scope) *Instance {
 				return newScope.Propagate()
 			}
 
+			// types match the values of the type, or implementing the protocol
+			if condition.IsType() && !exp.IsType() {
+				if isInstanceOf(exp, condition.AsType().DataType) {
+					current = i
+					break
+				}
+				continue
+			}
+
 			if AsBool(exp.OnEq(r, scope, condition)) {
 				current = i
 				break
@@ -1451,6 +2503,186 @@ func (r *Runtime) EvalMatch(node *ast.Match, scope *Scope) *Instance {
 	return ret
 }
 
+// Returns the variant named by the case pattern, such as `Circle(x)`,
+// `Shape.Circle(x)` or `Empty`, or nil if the pattern is not a variant
+func (r *Runtime) patternVariant(node ast.Node, enum *EnumType, scope *Scope) *EnumVariant {
+	if call, ok := node.(*ast.Call); ok {
+		node = call.Target
+	}
+
+	switch n := node.(type) {
+	case *ast.Identifier:
+		return enum.Variant(n.Value)
+
+	case *ast.Access:
+		right, ok := n.Right.(*ast.Identifier)
+		if !ok {
+			return nil
+		}
+
+		left, ok := n.Left.(*ast.Identifier)
+		if !ok || left.Value != enum.Name {
+			return nil
+		}
+
+		// the name must refer to the enum, not to a shadowing variable
+		if value, has := scope.Get(left.Value); !has || !value.IsType() || value.AsType().DataType != enum {
+			return nil
+		}
+
+		return enum.Variant(right.Value)
+	}
+
+	return nil
+}
+
+// Matches the value against the variant pattern, collecting the names bound
+// by it. Nested variants are matched recursively, underscores match anything
+// and other expressions are compared by equality.
+func (r *Runtime) matchVariant(node ast.Node, variant *EnumVariant, value *Instance, bindings map[string]*Instance, scope *Scope) bool {
+	this := value.AsEnum()
+	if this.Variant != variant {
+		return false
+	}
+
+	call, ok := node.(*ast.Call)
+	if !ok {
+		return true
+	}
+
+	if len(call.Arguments) != len(variant.Fields) {
+		r.Throw(Error.Create(scope, "variant '%s' has %d values, %d given in the pattern", variant.FullName(), len(variant.Fields), len(call.Arguments)), scope)
+		return false
+	}
+
+	for i, arg := range call.Arguments {
+		v := this.Values[i]
+		if r.isUnderscore(arg) {
+			continue
+		}
+
+		if v.IsEnum() {
+			if nested := r.patternVariant(arg, v.Type.(*EnumType), scope); nested != nil {
+				if !r.matchVariant(arg, nested, v, bindings, scope) {
+					return false
+				}
+				continue
+			}
+		}
+
+		// variant patterns of other enums, or used on other values, fail
+		if r.isForeignVariant(arg, scope) {
+			return false
+		}
+
+		if ident, ok := arg.(*ast.Identifier); ok {
+			// a repeated name matches only equal values
+			if bound, has := bindings[ident.Value]; has {
+				if bound.Type != v.Type || !AsBool(v.OnEq(r, scope, bound)) {
+					return false
+				}
+			}
+			bindings[ident.Value] = v
+			continue
+		}
+
+		expected := r.Eval(arg, scope)
+		if scope.IsInterruptedAs(FlowRaise) {
+			return false
+		}
+
+		if expected.Type != v.Type || !AsBool(v.OnEq(r, scope, expected)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Reports if the pattern names a variant not matching the value, such as a
+// call to an undefined name or to a member of an enum
+func (r *Runtime) isForeignVariant(node ast.Node, scope *Scope) bool {
+	call, ok := node.(*ast.Call)
+	if !ok {
+		return false
+	}
+
+	switch target := call.Target.(type) {
+	case *ast.Identifier:
+		_, has := scope.Get(target.Value)
+		return !has
+
+	case *ast.Access:
+		left, ok := target.Left.(*ast.Identifier)
+		if !ok {
+			return false
+		}
+
+		value, has := scope.Get(left.Value)
+		if !has || !value.IsType() {
+			return false
+		}
+		_, ok = value.AsType().DataType.(*EnumType)
+		return ok
+	}
+
+	return false
+}
+
+// Warns once for each match over an enum which does not handle all of its
+// variants, nor has a wildcard case
+func (r *Runtime) checkVariantsCovered(node *ast.Match, enum *EnumType, scope *Scope) {
+	if r.warnedMatches[node] {
+		return
+	}
+
+	covered := map[*EnumVariant]bool{}
+	for _, v := range node.Cases {
+		condition := v.(*ast.MatchCase).Condition
+		if r.isUnderscore(condition) {
+			return
+		}
+
+		variant := r.patternVariant(condition, enum, scope)
+		if variant != nil && r.isIrrefutable(condition) {
+			covered[variant] = true
+		}
+	}
+
+	missing := []string{}
+	for _, variant := range enum.Variants {
+		if !covered[variant] {
+			missing = append(missing, variant.Name)
+		}
+	}
+
+	if len(missing) == 0 {
+		return
+	}
+
+	if r.warnedMatches == nil {
+		r.warnedMatches = map[*ast.Match]bool{}
+	}
+	r.warnedMatches[node] = true
+
+	fmt.Fprintf(r.WarningOutput, "warning: match on '%s' does not cover the variants %s\n", enum.Name, strings.Join(missing, ", "))
+}
+
+// Reports if the variant pattern matches all the values of its variant
+func (r *Runtime) isIrrefutable(node ast.Node) bool {
+	call, ok := node.(*ast.Call)
+	if !ok {
+		return true
+	}
+
+	for _, arg := range call.Arguments {
+		if _, ok := arg.(*ast.Identifier); !ok {
+			return false
+		}
+	}
+	return true
+}
+
 func (r *Runtime) isUnderscore(node ast.Node) bool {
 	ident, ok := node.(*ast.Identifier)
 	if !ok {
@@ -17,6 +17,8 @@ type Scope struct {
 	Values       map[string]*Instance
 	ActiveRecord ExecutionRecord
 	Interruption *FlowInterruption
+	Generator    *GeneratorState
+	Deferred     []func() // called in reverse order when the scope exits
 
 	InMatchCase  bool
 	InAssignment bool
@@ -43,6 +45,7 @@ func CreateScope(parent *Scope, caller *Scope, propagateTo *Scope) *Scope {
 	if parent != nil {
 		s.Depth = parent.Depth + 1
 		s.Function = parent.Function
+		s.Generator = parent.Generator
 	}
 
 	return s
@@ -63,7 +66,7 @@ func (s *Scope) IsInterruptedAs(t ...FlowType) bool {
 }
 
 func (s *Scope) Interrupt(t FlowType, v *Instance) *Instance {
-	s.Interruption = &FlowInterruption{s, t, v}
+	s.Interruption = &FlowInterruption{s, t, v, ""}
 	return v
 }
 
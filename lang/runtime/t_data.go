@@ -3,6 +3,8 @@ package runtime
 import (
 	"sht/lang/ast"
 	"sht/lang/runtime/meta"
+	"sort"
+	"strings"
 )
 
 func CreateCustomType(
@@ -30,22 +32,232 @@ func CreateCustomType(
 	// 	println("......", k, v)
 	// }
 
-	return Type.Create(
-		&CustomType{
-			BaseDataType: BaseDataType{
-				Name:        name,
-				Properties:  properties,
-				StaticFns:   staticFns,
-				InstanceFns: instanceFns,
-			},
+	custom := &CustomType{
+		BaseDataType: BaseDataType{
+			Name:        name,
+			Properties:  properties,
+			StaticFns:   staticFns,
+			InstanceFns: instanceFns,
+		},
+		MetaFunctions: meta,
+		Validators:    map[string]*PropertyValidator{},
+		Computed:      map[string]*Instance{},
+		Declared: &CustomMembers{
+			Properties:    properties,
+			StaticFns:     staticFns,
+			InstanceFns:   instanceFns,
 			MetaFunctions: meta,
+			Validators:    map[string]*PropertyValidator{},
+			Computed:      map[string]*Instance{},
 		},
-	)
+	}
+	custom.Mro = []*CustomType{custom}
+
+	return Type.Create(custom)
 }
 
 type CustomType struct {
 	BaseDataType
 	MetaFunctions map[string]*Instance
+	Validators    map[string]*PropertyValidator // conditions given with `where`
+	Computed      map[string]*Instance          // read-only properties given with `get`
+
+	Bases    []*CustomType  // types given with `like`, in order
+	Mro      []*CustomType  // resolution order, starting with the type itself
+	Declared *CustomMembers // members declared by the type itself
+}
+
+// Members declared in a data definition, without the inherited ones
+type CustomMembers struct {
+	Properties    map[string]ast.Node
+	StaticFns     map[string]*Instance
+	InstanceFns   map[string]*Instance
+	MetaFunctions map[string]*Instance
+	Validators    map[string]*PropertyValidator
+	Computed      map[string]*Instance
+}
+
+// Condition a property value must hold, evaluated in the scope the type was
+// defined with the value and `this` bound
+type PropertyValidator struct {
+	Condition ast.Node
+	Scope     *Scope
+}
+
+// Reports if the name is of a private member, which only the functions of
+// the type declaring it can access
+func isPrivate(name string) bool {
+	return len(name) > 1 && strings.HasPrefix(name, "_")
+}
+
+// Returns the data type declaring the function running in the scope.
+// Functions nested in a data function still refer to its type.
+func ownerOf(scope *Scope) *CustomType {
+	for s := scope; s != nil; s = s.Parent {
+		if s.Function != nil && s.Function.IsFunction() {
+			if owner := s.Function.AsFunction().Owner; owner != nil {
+				return owner
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reports if the members declared by the type itself include the name
+func (m *CustomMembers) declares(name string) bool {
+	if _, ok := m.Properties[name]; ok {
+		return true
+	}
+	if _, ok := m.StaticFns[name]; ok {
+		return true
+	}
+	if _, ok := m.InstanceFns[name]; ok {
+		return true
+	}
+	_, ok := m.Computed[name]
+	return ok
+}
+
+// Returns the type declaring the member, following the resolution order
+func (d *CustomType) declarerOf(name string) *CustomType {
+	for _, t := range d.Mro {
+		if t.Declared.declares(name) {
+			return t
+		}
+	}
+
+	return d
+}
+
+// Reports if the scope can access the member, which for private members
+// requires running a function of the type declaring it
+func (d *CustomType) canAccess(s *Scope, name string) bool {
+	return !isPrivate(name) || ownerOf(s) == d.declarerOf(name)
+}
+
+// Returns the properties without the private ones the scope cannot access
+func (d *CustomType) visibleProperties(s *Scope, properties map[string]*Instance) map[string]*Instance {
+	visible := map[string]*Instance{}
+	for name, value := range properties {
+		if d.canAccess(s, name) {
+			visible[name] = value
+		}
+	}
+	return visible
+}
+
+// Raises an error if the member is private and the scope is not running a
+// function of the type declaring it
+func (d *CustomType) checkAccess(r *Runtime, s *Scope, name string) *Instance {
+	if d.canAccess(s, name) {
+		return nil
+	}
+
+	return r.Throw(Error.PrivateField(s, d.Name, name), s)
+}
+
+// Raises an error if the value does not hold the `where` condition of the
+// property
+func (d *CustomType) validate(r *Runtime, s *Scope, self *Instance, name string, value *Instance) *Instance {
+	validator, ok := d.Validators[name]
+	if !ok {
+		return nil
+	}
+
+	scope := CreateScope(validator.Scope, s, s)
+	scope.Set("this", self)
+	scope.Set(name, value)
+
+	res := r.Eval(validator.Condition, scope)
+	if scope.IsInterruptedAs(FlowRaise) {
+		return scope.Propagate()
+	}
+
+	if !AsBool(res) {
+		repr := AsString(value.OnRepr(r, s))
+		return r.Throw(Error.InvalidField(s, d.Name, name, "invalid value %s for field '%s' of type '%s'", repr, name, d.Name), s)
+	}
+
+	return nil
+}
+
+// Assigns the property after checking it can be set with the value
+func (d *CustomType) setProperty(r *Runtime, s *Scope, self *Instance, name string, value *Instance) *Instance {
+	if err := d.validate(r, s, self, name, value); err != nil {
+		return err
+	}
+
+	self.AsCustom().Properties[name] = value
+	return value
+}
+
+// Reports if the type is the given type or inherits from it
+func (d *CustomType) IsSubtypeOf(other DataType) bool {
+	for _, t := range d.Mro {
+		if t == other {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Computes the C3 linearization of the given bases, which is the order used
+// to resolve inherited members. The leftmost bases come first, and a type
+// always comes before its own bases. Reports false if the bases have no
+// consistent order.
+func linearize(bases []*CustomType) ([]*CustomType, bool) {
+	sequences := [][]*CustomType{}
+	for _, base := range bases {
+		sequences = append(sequences, append([]*CustomType{}, base.Mro...))
+	}
+	sequences = append(sequences, append([]*CustomType{}, bases...))
+
+	inTail := func(t *CustomType) bool {
+		for _, sequence := range sequences {
+			for _, other := range sequence[1:] {
+				if other == t {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	result := []*CustomType{}
+	for {
+		remaining := [][]*CustomType{}
+		for _, sequence := range sequences {
+			if len(sequence) > 0 {
+				remaining = append(remaining, sequence)
+			}
+		}
+		sequences = remaining
+
+		if len(sequences) == 0 {
+			return result, true
+		}
+
+		var next *CustomType
+		for _, sequence := range sequences {
+			if !inTail(sequence[0]) {
+				next = sequence[0]
+				break
+			}
+		}
+
+		if next == nil {
+			return nil, false
+		}
+
+		result = append(result, next)
+		for i, sequence := range sequences {
+			if sequence[0] == next {
+				sequences[i] = sequence[1:]
+			}
+		}
+	}
 }
 
 type CustomImpl struct {
@@ -60,8 +272,22 @@ func (d *CustomType) Instantiate(r *Runtime, s *Scope, init ast.Initializer) *In
 		return r.Throw(Error.Create(s, "Cannot instantiate custom type with list initializer"), s)
 
 	case *ast.MapInitializer:
-		for name, node := range init.Values {
-			properties[name] = r.Eval(node, s)
+		if init.Comprehension != nil {
+			return r.Throw(Error.Create(s, "Cannot instantiate custom type with a comprehension"), s)
+		}
+
+		for _, name := range initializerKeys(init) {
+			if err := d.checkAccess(r, s, name); err != nil {
+				return err
+			}
+			if d.Computed[name] != nil {
+				return r.Throw(Error.ReadOnlyField(s, d.Name, name), s)
+			}
+
+			properties[name] = r.Eval(init.Values[name], s)
+			if s.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+				return properties[name]
+			}
 		}
 	}
 
@@ -71,12 +297,28 @@ func (d *CustomType) Instantiate(r *Runtime, s *Scope, init ast.Initializer) *In
 		}
 	}
 
-	return &Instance{
+	self := &Instance{
 		Type: d,
 		Impl: &CustomImpl{
 			Properties: properties,
 		},
 	}
+
+	names := []string{}
+	for name := range d.Validators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if value, ok := properties[name]; ok {
+			if err := d.validate(r, s, self, name, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return self
 }
 
 func (d *CustomType) OnNew(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
@@ -98,11 +340,23 @@ func (d *CustomType) OnLen(r *Runtime, s *Scope, self *Instance, args ...*Instan
 	return r.Throw(Error.InvalidAction(s, string(meta.Len), self), s)
 }
 func (d *CustomType) OnSet(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if self.Frozen {
+		return r.Throw(Error.FrozenValue(s, self), s)
+	}
+
 	this := self.AsCustom()
 	name := AsString(args[0])
 	old, hasOld := this.Properties[name]
 	new := args[1]
 
+	if err := d.checkAccess(r, s, name); err != nil {
+		return err
+	}
+
+	if d.Computed[name] != nil {
+		return r.Throw(Error.ReadOnlyField(s, d.Name, name), s)
+	}
+
 	if fn := d.MetaFunctions[string(meta.SetProperty)]; fn != nil {
 		old = Error.NoProperty(s, d.Name, name)
 		new = fn.OnCall(r, s, self, args[0], old, new)
@@ -112,13 +366,20 @@ func (d *CustomType) OnSet(r *Runtime, s *Scope, self *Instance, args ...*Instan
 
 	}
 
-	this.Properties[name] = new
-	return new
+	return d.setProperty(r, s, self, name, new)
 }
 func (d *CustomType) OnGet(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	this := self.AsCustom()
 	name := AsString(args[0])
 
+	if err := d.checkAccess(r, s, name); err != nil {
+		return err
+	}
+
+	if fn := d.Computed[name]; fn != nil {
+		return fn.OnCall(r, s, self)
+	}
+
 	value, has := this.Properties[name]
 	if d.InstanceFns[name] != nil {
 		value = d.InstanceFns[name]
@@ -169,6 +430,12 @@ func (d *CustomType) OnString(r *Runtime, s *Scope, self *Instance, args ...*Ins
 }
 func (d *CustomType) OnRepr(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	if fn := d.MetaFunctions[string(meta.Repr)]; fn != nil {
+		r, ok := enterRepr(r, self)
+		if !ok {
+			return String.Create(cycleRepr)
+		}
+		defer leaveRepr(r, self)
+
 		ret := fn.OnCall(r, s, append([]*Instance{self}, args...)...)
 
 		if !ret.IsString() {
@@ -209,47 +476,65 @@ func (d *CustomType) OnIter(r *Runtime, s *Scope, self *Instance, args ...*Insta
 	}
 	return r.Throw(Error.InvalidAction(s, string(meta.Iter), self), s)
 }
+func (d *CustomType) OnClose(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if fn := d.MetaFunctions[string(meta.Close)]; fn != nil {
+		return fn.OnCall(r, s, append([]*Instance{self}, args...)...)
+	}
+	return r.Throw(Error.InvalidAction(s, string(meta.Close), self), s)
+}
+func (d *CustomType) OnHash(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if fn := d.MetaFunctions[string(meta.Hash)]; fn != nil {
+		ret := fn.OnCall(r, s, append([]*Instance{self}, args...)...)
+
+		if !ret.IsNumber() {
+			return r.Throw(Error.Create(s, "Expected number on meta function '%s', got %s", string(meta.Hash), ret.Type.GetName()), s)
+		}
+
+		return ret
+	}
+	return d.BaseDataType.OnHash(r, s, self, args...)
+}
 func (d *CustomType) OnAdd(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	if fn := d.MetaFunctions[string(meta.Add)]; fn != nil {
 		return fn.OnCall(r, s, append([]*Instance{self}, args...)...)
 	}
-	return r.Throw(Error.InvalidAction(s, string(meta.Add), self), s)
+	return reflectOperator(r, s, meta.Add, self, args[0], Error.InvalidAction(s, string(meta.Add), self))
 }
 func (d *CustomType) OnSub(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	if fn := d.MetaFunctions[string(meta.Sub)]; fn != nil {
 		return fn.OnCall(r, s, append([]*Instance{self}, args...)...)
 	}
-	return r.Throw(Error.InvalidAction(s, string(meta.Sub), self), s)
+	return reflectOperator(r, s, meta.Sub, self, args[0], Error.InvalidAction(s, string(meta.Sub), self))
 }
 func (d *CustomType) OnMul(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	if fn := d.MetaFunctions[string(meta.Mul)]; fn != nil {
 		return fn.OnCall(r, s, append([]*Instance{self}, args...)...)
 	}
-	return r.Throw(Error.InvalidAction(s, string(meta.Mul), self), s)
+	return reflectOperator(r, s, meta.Mul, self, args[0], Error.InvalidAction(s, string(meta.Mul), self))
 }
 func (d *CustomType) OnDiv(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	if fn := d.MetaFunctions[string(meta.Div)]; fn != nil {
 		return fn.OnCall(r, s, append([]*Instance{self}, args...)...)
 	}
-	return r.Throw(Error.InvalidAction(s, string(meta.Div), self), s)
+	return reflectOperator(r, s, meta.Div, self, args[0], Error.InvalidAction(s, string(meta.Div), self))
 }
 func (d *CustomType) OnIntDiv(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	if fn := d.MetaFunctions[string(meta.IntDiv)]; fn != nil {
 		return fn.OnCall(r, s, append([]*Instance{self}, args...)...)
 	}
-	return r.Throw(Error.InvalidAction(s, string(meta.IntDiv), self), s)
+	return reflectOperator(r, s, meta.IntDiv, self, args[0], Error.InvalidAction(s, string(meta.IntDiv), self))
 }
 func (d *CustomType) OnMod(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	if fn := d.MetaFunctions[string(meta.Mod)]; fn != nil {
 		return fn.OnCall(r, s, append([]*Instance{self}, args...)...)
 	}
-	return r.Throw(Error.InvalidAction(s, string(meta.Mod), self), s)
+	return reflectOperator(r, s, meta.Mod, self, args[0], Error.InvalidAction(s, string(meta.Mod), self))
 }
 func (d *CustomType) OnPow(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	if fn := d.MetaFunctions[string(meta.Pow)]; fn != nil {
 		return fn.OnCall(r, s, append([]*Instance{self}, args...)...)
 	}
-	return r.Throw(Error.InvalidAction(s, string(meta.Pow), self), s)
+	return reflectOperator(r, s, meta.Pow, self, args[0], Error.InvalidAction(s, string(meta.Pow), self))
 }
 func (d *CustomType) OnEq(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	if fn := d.MetaFunctions[string(meta.Eq)]; fn != nil {
@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"sht/lang/ast"
+	"sort"
 	"strings"
 )
 
@@ -34,6 +35,7 @@ func (t *DictInfo) Create(values map[string]*Instance) *Instance {
 				"default": ThrowFn,
 			},
 			Values: values,
+			Keys:   map[string]*Instance{},
 		},
 	}
 }
@@ -53,10 +55,17 @@ type DictDataType struct {
 func (d *DictDataType) Instantiate(r *Runtime, s *Scope, init ast.Initializer) *Instance {
 	switch init := init.(type) {
 	case *ast.MapInitializer:
+		if init.Comprehension != nil {
+			return d.OnTo(r, s, r.EvalComprehension(init.Comprehension, s))
+		}
+
 		values := map[string]*Instance{}
 
-		for k, v := range init.Values {
-			values[k] = r.Eval(v, s)
+		for _, k := range initializerKeys(init) {
+			values[k] = r.Eval(init.Values[k], s)
+			if s.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+				return values[k]
+			}
 		}
 
 		return Dict.Create(values)
@@ -67,10 +76,21 @@ func (d *DictDataType) Instantiate(r *Runtime, s *Scope, init ast.Initializer) *
 	}
 }
 
+// Returns the keys of the map initializer in order, so its values are always
+// evaluated in the same order
+func initializerKeys(init *ast.MapInitializer) []string {
+	keys := make([]string, 0, len(init.Values))
+	for key := range init.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func (d *DictDataType) OnTo(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	iter := self.AsIterator()
 	next := iter.next()
-	values := map[string]*Instance{}
+	dict := Dict.Create(map[string]*Instance{})
 	for {
 		tion := next.OnCall(r, s, self).AsIteration()
 
@@ -79,15 +99,20 @@ func (d *DictDataType) OnTo(r *Runtime, s *Scope, self *Instance, args ...*Insta
 			return r.Throw(tuple.Values[0], s)
 
 		} else if AsBool(tion.done()) {
-			return Dict.Create(values)
+			return dict
 
 		} else {
 			tuple := tion.value().AsTuple()
+			if len(tuple.Values) == 1 && tuple.Values[0].IsTuple() {
+				// pipes such as `zip` and `enumerate` emit a single tuple value
+				tuple = tuple.Values[0].AsTuple()
+			}
+
 			if len(tuple.Values) < 2 {
 				return r.Throw(Error.Create(s, "invalid tuple for dict, dict requires two elements as (key, value)"), s)
 			}
 
-			values[AsString(tuple.Values[0])] = tuple.Values[1]
+			dict.AsDict().Values[dict.AsDict().addKey(r, s, tuple.Values[0])] = tuple.Values[1]
 		}
 	}
 }
@@ -119,7 +144,7 @@ func (d *DictDataType) OnIter(r *Runtime, s *Scope, self *Instance, args ...*Ins
 			cur++
 			k := keys[cur-1]
 			return Iteration.Create(
-				String.Create(k),
+				this.key(k),
 				this.Values[k],
 			)
 		}),
@@ -127,6 +152,10 @@ func (d *DictDataType) OnIter(r *Runtime, s *Scope, self *Instance, args ...*Ins
 }
 
 func (d *DictDataType) OnSet(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if self.Frozen {
+		return r.Throw(Error.FrozenValue(s, self), s)
+	}
+
 	this := self.AsDict()
 	name := AsString(args[0])
 
@@ -163,14 +192,18 @@ func (t *DictDataType) OnGetItem(r *Runtime, s *Scope, self *Instance, args ...*
 		return r.Throw(Error.Create(s, "dict getItem receives only one index, '%d' provided", len(args)), s)
 	}
 
-	key := AsString(args[0])
+	key := this.keyOf(r, s, args[0])
+	if s.IsInterruptedAs(FlowRaise) {
+		return Boolean.FALSE
+	}
 
 	if _, has := this.Values[key]; !has {
 		val := this.default_().OnCall(r, s, self)
-		if s.IsInterruptedAs(FlowRaise) {
+		if s.IsInterruptedAs(FlowRaise) || self.Frozen {
 			return val
 		}
 
+		key = this.addKey(r, s, args[0])
 		this.Values[key] = val
 	}
 
@@ -178,6 +211,10 @@ func (t *DictDataType) OnGetItem(r *Runtime, s *Scope, self *Instance, args ...*
 }
 
 func (t *DictDataType) OnSetItem(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if self.Frozen {
+		return r.Throw(Error.FrozenValue(s, self), s)
+	}
+
 	this := self.AsDict()
 
 	nargs := len(args)
@@ -185,21 +222,39 @@ func (t *DictDataType) OnSetItem(r *Runtime, s *Scope, self *Instance, args ...*
 		return r.Throw(Error.Create(s, "dict setItem receives only one index, '%d' provided", nargs), s)
 	}
 
-	name := AsString(args[0])
-	this.Values[name] = args[1]
+	key := this.addKey(r, s, args[0])
+	if s.IsInterruptedAs(FlowRaise) {
+		return Boolean.FALSE
+	}
+
+	this.Values[key] = args[1]
 	return args[1]
 }
 
+func (d *DictDataType) OnEq(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return Boolean.Create(deepEqual(r, s, self, args[0], nil))
+}
+
+func (d *DictDataType) OnNeq(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return Boolean.Create(!deepEqual(r, s, self, args[0], nil))
+}
+
 func (d *DictDataType) OnString(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	return d.OnRepr(r, s, self)
 }
 
 func (d *DictDataType) OnRepr(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	r, ok := enterRepr(r, self)
+	if !ok {
+		return String.Create(cycleRepr)
+	}
+	defer leaveRepr(r, self)
+
 	dict := self.AsDict()
 
 	var values []string
 	for key, value := range dict.Values {
-		values = append(values, key+": "+value.Repr())
+		values = append(values, reprOf(r, s, dict.key(key))+": "+reprOf(r, s, value))
 	}
 
 	return String.Create("{" + strings.Join(values, ", ") + "}")
@@ -211,6 +266,41 @@ func (d *DictDataType) OnRepr(r *Runtime, s *Scope, self *Instance, args ...*Ins
 type DictDataImpl struct {
 	Properties map[string]*Instance
 	Values     map[string]*Instance
+	Keys       map[string]*Instance // original keys of the entries not keyed by a string
+}
+
+// Returns the key of the value in the dict. Strings, numbers and booleans are
+// keyed by their string, other values by their hash key.
+func (impl *DictDataImpl) keyOf(r *Runtime, s *Scope, value *Instance) string {
+	if value.IsString() || value.IsNumber() || value.IsBoolean() {
+		return AsString(value)
+	}
+
+	key, _ := hashedKey(r, s, value, impl.Keys)
+	return key
+}
+
+// Returns the key of the value as keyOf does, recording the value when it is
+// new to the dict. Lists, dicts and sets are kept as frozen copies, so later
+// changes don't move the entry.
+func (impl *DictDataImpl) addKey(r *Runtime, s *Scope, value *Instance) string {
+	if value.IsString() || value.IsNumber() || value.IsBoolean() {
+		return AsString(value)
+	}
+
+	key, has := hashedKey(r, s, value, impl.Keys)
+	if !has && !s.IsInterruptedAs(FlowRaise) {
+		impl.Keys[key] = keySnapshot(value, nil)
+	}
+	return key
+}
+
+// Returns the value used as the given key
+func (impl *DictDataImpl) key(key string) *Instance {
+	if original, has := impl.Keys[key]; has {
+		return original
+	}
+	return String.Create(key)
 }
 
 func (impl *DictDataImpl) default_() *Instance {
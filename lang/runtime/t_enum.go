@@ -0,0 +1,181 @@
+package runtime
+
+import (
+	"sht/lang/ast"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// ENUM DATA TYPE
+// ----------------------------------------------------------------------------
+type EnumType struct {
+	BaseDataType
+	Variants []*EnumVariant
+}
+
+// A variant of an enum, holding the unit value or the constructor of the
+// variants with fields
+type EnumVariant struct {
+	Enum   *EnumType
+	Name   string
+	Fields []string
+	Value  *Instance
+}
+
+func CreateEnumType(name string) *EnumType {
+	return &EnumType{
+		BaseDataType: BaseDataType{
+			Name:        name,
+			Properties:  map[string]ast.Node{},
+			StaticFns:   map[string]*Instance{},
+			InstanceFns: map[string]*Instance{},
+		},
+		Variants: []*EnumVariant{},
+	}
+}
+
+// Adds a variant to the enum, which is accessible as a static member
+func (d *EnumType) AddVariant(name string, fields []string) *EnumVariant {
+	variant := &EnumVariant{
+		Enum:   d,
+		Name:   name,
+		Fields: fields,
+	}
+
+	if len(fields) == 0 {
+		variant.Value = variant.Create()
+
+	} else {
+		params := []*FunctionParam{}
+		for _, field := range fields {
+			params = append(params, &FunctionParam{Name: field})
+		}
+
+		variant.Value = Function.CreateNative(name, params, func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			// accessed through the enum, the type is given as the first argument
+			if len(args) > 0 && args[0].IsType() && args[0].AsType().DataType == d {
+				args = args[1:]
+			}
+
+			if len(args) != len(fields) {
+				return throw(r, s, "variant '%s' expects %d values, %d given", variant.FullName(), len(fields), len(args))
+			}
+			return variant.Create(args...)
+		})
+	}
+
+	d.Variants = append(d.Variants, variant)
+	d.StaticFns[name] = variant.Value
+	return variant
+}
+
+func (d *EnumType) Variant(name string) *EnumVariant {
+	for _, variant := range d.Variants {
+		if variant.Name == name {
+			return variant
+		}
+	}
+	return nil
+}
+
+func (d *EnumType) Instantiate(r *Runtime, s *Scope, init ast.Initializer) *Instance {
+	return r.Throw(Error.Create(s, "enum '%s' cannot be instantiated, use one of its variants", d.Name), s)
+}
+
+func (d *EnumType) OnGet(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	this := self.AsEnum()
+	name := AsString(args[0])
+	for i, field := range this.Variant.Fields {
+		if field == name {
+			return this.Values[i]
+		}
+	}
+
+	return r.Throw(Error.NoProperty(s, this.Variant.FullName(), name), s)
+}
+
+func (d *EnumType) OnEq(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if !args[0].IsEnum() {
+		return Boolean.FALSE
+	}
+
+	this := self.AsEnum()
+	other := args[0].AsEnum()
+	if this.Variant != other.Variant {
+		return Boolean.FALSE
+	}
+
+	for i, value := range this.Values {
+		o := other.Values[i]
+		if value.Type == WildCard.Type || o.Type == WildCard.Type {
+			continue
+		}
+
+		if value.Type != o.Type || !AsBool(value.OnEq(r, s, o)) {
+			return Boolean.FALSE
+		}
+	}
+
+	return Boolean.TRUE
+}
+
+func (d *EnumType) OnNeq(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return Boolean.Create(!AsBool(d.OnEq(r, s, self, args...)))
+}
+
+func (d *EnumType) OnString(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return d.OnRepr(r, s, self)
+}
+
+func (d *EnumType) OnRepr(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	r, ok := enterRepr(r, self)
+	if !ok {
+		return String.Create(cycleRepr)
+	}
+	defer leaveRepr(r, self)
+
+	this := self.AsEnum()
+	if len(this.Variant.Fields) == 0 {
+		return String.Create(this.Variant.FullName())
+	}
+
+	values := []string{}
+	for _, value := range this.Values {
+		values = append(values, reprOf(r, s, value))
+	}
+
+	return String.Createf("%s(%s)", this.Variant.FullName(), strings.Join(values, ", "))
+}
+
+// Iterates over the variants of the enum, in declaration order. Variants
+// without fields are given as their value, the others as their constructor.
+func (d *EnumType) iterVariants() *Instance {
+	values := []*Instance{}
+	for _, variant := range d.Variants {
+		values = append(values, variant.Value)
+	}
+
+	return tupleDT.OnIter(nil, nil, Tuple.Create(values...))
+}
+
+func (v *EnumVariant) FullName() string {
+	return v.Enum.Name + "." + v.Name
+}
+
+func (v *EnumVariant) Create(values ...*Instance) *Instance {
+	return &Instance{
+		Type: v.Enum,
+		Impl: &EnumDataImpl{
+			Variant: v,
+			Values:  values,
+		},
+	}
+}
+
+// ----------------------------------------------------------------------------
+// ENUM DATA IMPL
+// ----------------------------------------------------------------------------
+type EnumDataImpl struct {
+	Variant *EnumVariant
+	Values  []*Instance
+}
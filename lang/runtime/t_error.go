@@ -113,6 +113,27 @@ func (t *ErrorInfo) VariableNotDefined(s *Scope, name string) *Instance {
 	return Error.Create(s, "trying to use an unidentified variable '%s'", name)
 }
 
+// Creates an error about a field of a data type, keeping the type and field
+// names in the `type` and `field` properties
+func (t *ErrorInfo) InvalidField(s *Scope, typeName string, field string, message string, a ...any) *Instance {
+	err := t.Create(s, message, a...)
+	err.Impl.(*ErrorDataImpl).Properties["type"] = String.Create(typeName)
+	err.Impl.(*ErrorDataImpl).Properties["field"] = String.Create(field)
+	return err
+}
+
+func (t *ErrorInfo) ReadOnlyField(s *Scope, typeName string, field string) *Instance {
+	return t.InvalidField(s, typeName, field, "field '%s' of type '%s' is read-only", field, typeName)
+}
+
+func (t *ErrorInfo) PrivateField(s *Scope, typeName string, field string) *Instance {
+	return t.InvalidField(s, typeName, field, "field '%s' of type '%s' is private", field, typeName)
+}
+
+func (t *ErrorInfo) FrozenValue(s *Scope, t1 *Instance) *Instance {
+	return Error.Create(s, "cannot modify frozen value of type '%s'", t1.Type.GetName())
+}
+
 func (t *ErrorInfo) NoProperty(s *Scope, typeName string, name string) *Instance {
 	return Error.Create(s, "instance of type '%s' does not have property '%s'", typeName, name)
 }
@@ -153,7 +174,7 @@ func (d *ErrorDataType) OnSet(r *Runtime, s *Scope, self *Instance, args ...*Ins
 
 func (d *ErrorDataType) OnGet(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	this := self.Impl.(*ErrorDataImpl)
-	name := AsString(args[1])
+	name := AsString(args[0])
 
 	value, has := this.Properties[name]
 	if !has {
@@ -98,12 +98,88 @@ type FunctionDataImpl struct {
 	NativeFn    MetaFunction
 	Generator   bool
 	Piped       bool
+	RawKeywords bool        // native function receives the keyword arguments unbound
+	Owner       *CustomType // data type declaring the function, used by super
+}
+
+// Execution state of a generator call, shared by the scopes of its body, so
+// a suspended yield can receive the value given to `send` when resumed
+type GeneratorState struct {
+	Pending  ast.Node  // yield waiting to be resumed
+	Sent     *Instance // value given to the pending yield
+	Delegate *Instance // iterator of the running `yield from`
+	Closing  bool
+
+	delegated bool // the last yield forwarded an iteration of the delegate
+
+	// nodes being evaluated, and the values of the child nodes completed
+	// before a yield suspended them. A resumed node replays those values
+	// instead of evaluating the children again, so the expression around a
+	// yield continues where it stopped.
+	frames    []*evalFrame
+	suspended map[ast.Node][]*Instance
+}
+
+type evalFrame struct {
+	resumable bool        // statements with a record resume on their own
+	results   []*Instance // values of the completed child nodes
+	replay    []*Instance // values still to be given to the child nodes
+}
+
+// Starts the evaluation of the node, returning the value of the node instead
+// if it completed before the generator was suspended
+func (gen *GeneratorState) enter(node ast.Node) (*Instance, bool) {
+	if n := len(gen.frames); n > 0 {
+		top := gen.frames[n-1]
+		if len(top.replay) > 0 {
+			value := top.replay[0]
+			top.replay = top.replay[1:]
+			top.results = append(top.results, value)
+			return value, true
+		}
+	}
+
+	frame := &evalFrame{}
+	switch node.(type) {
+	case *ast.Block, *ast.If, *ast.For, *ast.Match, *ast.With, *ast.PipeLoop:
+	default:
+		frame.resumable = true
+		frame.replay = gen.suspended[node]
+		delete(gen.suspended, node)
+	}
+
+	gen.frames = append(gen.frames, frame)
+	return nil, false
+}
+
+// Finishes the evaluation of the node, keeping the values of its completed
+// children when it is suspended by a yield
+func (gen *GeneratorState) leave(node ast.Node, result *Instance, scope *Scope) {
+	n := len(gen.frames)
+	frame := gen.frames[n-1]
+	gen.frames = gen.frames[:n-1]
+
+	if scope.IsInterruptedAs(FlowYield) {
+		if frame.resumable && len(frame.results) > 0 {
+			if gen.suspended == nil {
+				gen.suspended = map[ast.Node][]*Instance{}
+			}
+			gen.suspended[node] = frame.results
+		}
+		return
+	}
+
+	if scope.Interruption == nil && n > 1 && gen.frames[n-2].resumable {
+		parent := gen.frames[n-2]
+		parent.results = append(parent.results, result)
+	}
 }
 
 type FunctionParam struct {
 	Name    string
 	Default *Instance
 	Spread  bool
+	Pattern ast.Node // destructuring pattern, for parameters without name
 }
 
 func (d *FunctionDataImpl) Call(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
@@ -117,6 +193,14 @@ func (d *FunctionDataImpl) Call(r *Runtime, s *Scope, self *Instance, args ...*I
 	scope.Function = self
 
 	if d.NativeFn != nil {
+		if !d.RawKeywords && hasKeywordArguments(args) {
+			var e *Instance
+			args, e = d.bindNative(r, scope, args)
+			if e != nil {
+				return scope.Propagate()
+			}
+		}
+
 		res := d.NativeFn(r, scope, self, args...)
 
 		if scope.IsInterruptedAs(FlowRaise) {
@@ -140,46 +224,43 @@ func (d *FunctionDataImpl) Call(r *Runtime, s *Scope, self *Instance, args ...*I
 		args = newargs
 	}
 
-	arguments := []*Instance{}
-	paramsLength := len(d.Params)
-	argsLength := len(args)
+	arguments, e := d.bind(r, scope, args)
+	if e != nil {
+		return scope.Propagate()
+	}
 
-	j := 0
 	for i, pv := range d.Params {
-		if pv.Spread {
-			spreadAmount := (argsLength - i) - (paramsLength - i - 1)
-			spreadItems := []*Instance{}
-
-			for k := 0; k < spreadAmount; k++ {
-				spreadItems = append(spreadItems, args[j])
-				j++
-			}
-
-			rv := List.Create(spreadItems...)
-			arguments = append(arguments, rv)
+		if arguments[i] != nil {
+			continue
+		}
 
-		} else {
-			if j >= argsLength {
-				if pv.Default == nil {
-					return r.Throw(Error.Create(scope, "missing arguments for parameter '%s'", pv.Name), scope)
-				} else {
-					arguments = append(arguments, pv.Default)
-				}
-			} else {
-				arguments = append(arguments, args[j])
-				j++
+		if pv.Default == nil {
+			if pv.Pattern != nil {
+				return r.Throw(Error.Create(scope, "missing arguments for destructured parameter %d", i+1), scope)
 			}
+			return r.Throw(Error.Create(scope, "missing arguments for parameter '%s'", pv.Name), scope)
 		}
+		arguments[i] = pv.Default
 	}
 
 	for i, pv := range d.Params {
-		if pv.Name != "_" {
+		if pv.Pattern != nil {
+			r.ResolveAssignment(pv.Pattern, arguments[i], &ast.Assignment{Definition: true}, scope)
+			if scope.IsInterruptedAs(FlowRaise) {
+				return scope.Propagate()
+			}
+
+		} else if pv.Name != "_" {
 			scope.Set(pv.Name, arguments[i])
 		}
 	}
 
 	if d.Generator {
-		iter := Iterator.Create(Function.CreateNative("generator", []*FunctionParam{}, func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+		gen := &GeneratorState{}
+		scope.Generator = gen
+
+		var iter *Instance
+		iter = Iterator.Create(Function.CreateNative("generator", []*FunctionParam{}, func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 			res := r.Eval(d.Body, scope)
 
 			if scope.IsInterruptedAs(FlowRaise) {
@@ -187,12 +268,21 @@ func (d *FunctionDataImpl) Call(r *Runtime, s *Scope, self *Instance, args ...*I
 
 			} else if scope.IsInterruptedAs(FlowYield) {
 				scope.Interruption = nil
+				if gen.delegated {
+					gen.delegated = false
+					return Iteration.CreateAsTuple(res)
+				}
 				return Iteration.Create(res)
 
 			} else {
+				if scope.IsInterruptedAs(FlowReturn) {
+					iter.AsIterator().Properties["result"] = scope.Interruption.Value
+					scope.Interruption = nil
+				}
 				return Iteration.DONE
 			}
 		}))
+		iter.AsIterator().Generator = gen
 
 		return iter
 
@@ -209,3 +299,186 @@ func (d *FunctionDataImpl) Call(r *Runtime, s *Scope, self *Instance, args ...*I
 		return res
 	}
 }
+
+// Binds the arguments to the function parameters, returning the value given
+// to each parameter, or nil for the ones without value. Spread parameters
+// receive a list with the remaining positional arguments, and the parameters
+// after them can only be given by keyword or by the last positional
+// arguments.
+func (d *FunctionDataImpl) bind(r *Runtime, s *Scope, args []*Instance) ([]*Instance, *Instance) {
+	positional, keywords, e := splitKeywordArguments(r, s, args)
+	if e != nil {
+		return nil, e
+	}
+
+	named := map[string]bool{}
+	for _, keyword := range keywords {
+		named[keyword.Name] = true
+	}
+
+	arguments := make([]*Instance, len(d.Params))
+	argsLength := len(positional)
+
+	j := 0
+	afterSpread := false
+	for i, pv := range d.Params {
+		if pv.Spread {
+			afterSpread = true
+
+			trailing := 0
+			for _, next := range d.Params[i+1:] {
+				if !next.Spread && !named[next.Name] {
+					trailing++
+				}
+			}
+
+			spreadItems := []*Instance{}
+			for j < argsLength-trailing {
+				spreadItems = append(spreadItems, positional[j])
+				j++
+			}
+
+			arguments[i] = List.Create(spreadItems...)
+
+		} else if afterSpread && named[pv.Name] {
+			continue
+
+		} else if j < argsLength {
+			arguments[i] = positional[j]
+			j++
+		}
+	}
+
+	for _, keyword := range keywords {
+		idx := d.paramIndex(keyword.Name)
+		if idx < 0 {
+			return nil, r.Throw(Error.Create(s, "unknown keyword argument '%s' for function '%s'", keyword.Name, d.Name), s)
+		}
+
+		if d.Params[idx].Spread {
+			return nil, r.Throw(Error.Create(s, "spread parameter '%s' cannot receive a keyword argument", keyword.Name), s)
+		}
+
+		if arguments[idx] != nil {
+			return nil, r.Throw(Error.Create(s, "duplicated argument for parameter '%s'", keyword.Name), s)
+		}
+
+		arguments[idx] = keyword.Value
+	}
+
+	return arguments, nil
+}
+
+// Converts the keyword arguments of a native function call into positional
+// ones. Native functions receive their arguments as given, so the parameters
+// skipped by the keywords receive their default value or false, which the
+// builtin validation handles as a missing argument.
+func (d *FunctionDataImpl) bindNative(r *Runtime, s *Scope, args []*Instance) ([]*Instance, *Instance) {
+	arguments, e := d.bind(r, s, args)
+	if e != nil {
+		return nil, e
+	}
+
+	last := -1
+	for i, arg := range arguments {
+		if arg != nil && !(d.Params[i].Spread && len(arg.AsList().Values) == 0) {
+			last = i
+		}
+	}
+
+	result := []*Instance{}
+	for i, arg := range arguments[:last+1] {
+		pv := d.Params[i]
+		switch {
+		case pv.Spread:
+			result = append(result, arg.AsList().Values...)
+		case arg != nil:
+			result = append(result, arg)
+		case pv.Default != nil:
+			result = append(result, pv.Default)
+		default:
+			result = append(result, Boolean.FALSE)
+		}
+	}
+
+	return result, nil
+}
+
+func (d *FunctionDataImpl) paramIndex(name string) int {
+	for i, pv := range d.Params {
+		if pv.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Reports if the argument is a hole to be filled by a partial application,
+// either as `_` or as `name=_`
+func isHole(arg *Instance) bool {
+	if arg.IsKeywordArgument() {
+		arg = arg.AsKeywordArgument().Value
+	}
+
+	return arg == WildCard.UNDERSCORE
+}
+
+// Creates the function returned by a call with `_` arguments. Positional
+// arguments given to it fill the holes in order, while the remaining ones are
+// appended to the original positional arguments and keyword arguments are
+// appended at the end.
+func createPartial(target *Instance, call func(r *Runtime, s *Scope, args []*Instance) *Instance, args []*Instance) *Instance {
+	name := "partial"
+	if target.IsFunction() {
+		name = target.Impl.(*FunctionDataImpl).Name
+	}
+
+	holes := 0
+	for _, arg := range args {
+		if isHole(arg) {
+			holes++
+		}
+	}
+
+	partial := Function.CreateNative(name, []*FunctionParam{}, func(r *Runtime, s *Scope, self *Instance, given ...*Instance) *Instance {
+		positional, keywords, e := splitKeywordArguments(r, s, given)
+		if e != nil {
+			return e
+		}
+
+		if len(positional) < holes {
+			return r.Throw(Error.Create(s, "partial function '%s' expects %d arguments, %d given", name, holes, len(positional)), s)
+		}
+
+		filled := []*Instance{}
+		extra := positional[holes:]
+		for _, arg := range args {
+			if arg.IsKeywordArgument() && len(extra) > 0 {
+				filled = append(filled, extra...)
+				extra = nil
+			}
+
+			switch {
+			case !isHole(arg):
+				filled = append(filled, arg)
+			case arg.IsKeywordArgument():
+				filled = append(filled, KeywordArgument.Create(arg.AsKeywordArgument().Name, positional[0]))
+				positional = positional[1:]
+			default:
+				filled = append(filled, positional[0])
+				positional = positional[1:]
+			}
+		}
+
+		filled = append(filled, extra...)
+		for _, keyword := range keywords {
+			filled = append(filled, KeywordArgument.Create(keyword.Name, keyword.Value))
+		}
+
+		return call(r, s, filled)
+	})
+
+	partial.Impl.(*FunctionDataImpl).RawKeywords = true
+	return partial
+}
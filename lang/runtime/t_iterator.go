@@ -29,6 +29,8 @@ func (t *IteratorInfo) Setup() {
 	t.TypeInstance = Type.Create(Iterator.Type)
 	t.TypeInstance.Impl.(*TypeDataImpl).TypeInstance = t.TypeInstance
 	t.Type.SetInstanceFn("next", Iterator_Next)
+	t.Type.SetInstanceFn("send", Iterator_Send)
+	t.Type.SetInstanceFn("close", Iterator_Close)
 }
 
 func (t *IteratorInfo) Create(nextFn *Instance) *Instance {
@@ -36,7 +38,8 @@ func (t *IteratorInfo) Create(nextFn *Instance) *Instance {
 		Type: t.Type,
 		Impl: &IteratorDataImpl{
 			Properties: map[string]*Instance{
-				"done": Boolean.FALSE,
+				"done":   Boolean.FALSE,
+				"result": Boolean.FALSE,
 			},
 			Next: nextFn,
 		},
@@ -58,6 +61,10 @@ func (d *IteratorDataType) OnIter(r *Runtime, s *Scope, self *Instance, args ...
 	return self
 }
 
+func (d *IteratorDataType) OnClose(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return Iterator_Close.OnCall(r, s, self)
+}
+
 func (d *IteratorDataType) OnGet(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	this := self.Impl.(*IteratorDataImpl)
 	name := AsString(args[0])
@@ -110,12 +117,18 @@ func (d *IteratorDataType) OnRepr(r *Runtime, s *Scope, self *Instance, args ...
 type IteratorDataImpl struct {
 	Properties map[string]*Instance
 	Next       *Instance
+	Generator  *GeneratorState // only for iterators created by generators
 }
 
 func (impl *IteratorDataImpl) done() *Instance {
 	return impl.Properties["done"]
 }
 
+// The value returned by the generator, available once it is done
+func (impl *IteratorDataImpl) result() *Instance {
+	return impl.Properties["result"]
+}
+
 func (impl *IteratorDataImpl) next() *Instance {
 	return Iterator.Type.GetInstanceFn("next")
 }
@@ -150,3 +163,46 @@ var Iterator_Next = Function.CreateNative("next", []*FunctionParam{}, func(r *Ru
 
 	return ret
 })
+
+// Resumes the generator, making the suspended yield evaluate to the given
+// value
+var Iterator_Send = Function.CreateNative("send", []*FunctionParam{{Name: "value"}}, func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	this := args[0].AsIterator()
+	if this.Generator == nil {
+		return r.Throw(Error.Create(s, "send is only available for generators"), s)
+	}
+
+	if len(args) < 2 {
+		return r.Throw(Error.Create(s, "send requires the value to be sent"), s)
+	}
+
+	if this.Generator.Pending != nil {
+		this.Generator.Sent = args[1]
+	}
+
+	return Iterator_Next.OnCall(r, s, args[0])
+})
+
+// Finishes the iterator. A suspended generator is resumed as if the pending
+// yield were a return, so it can run its cleanup code.
+var Iterator_Close = Function.CreateNative("close", []*FunctionParam{}, func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	this := args[0].AsIterator()
+	if AsBool(this.done()) {
+		return this.result()
+	}
+
+	gen := this.Generator
+	if gen != nil && gen.Pending != nil {
+		gen.Closing = true
+		ret := this.Next.OnCall(r, s, args[0])
+		gen.Closing = false
+
+		if ret.IsIteration() && AsBool(ret.AsIteration().error()) {
+			this.Properties["done"] = Boolean.TRUE
+			return r.Throw(ret.AsIteration().value().AsTuple().Values[0], s)
+		}
+	}
+
+	this.Properties["done"] = Boolean.TRUE
+	return this.result()
+})
@@ -0,0 +1,108 @@
+package runtime
+
+import (
+	"sht/lang/ast"
+)
+
+var keywordArgumentDT = &KeywordArgumentDataType{
+	BaseDataType: BaseDataType{
+		Name:        "KeywordArgument",
+		Properties:  map[string]ast.Node{},
+		StaticFns:   map[string]*Instance{},
+		InstanceFns: map[string]*Instance{},
+	},
+}
+
+// KeywordArgument wraps the `name=value` arguments of a call, so they can
+// travel through the regular argument list until the function binds them to
+// its parameters.
+var KeywordArgument = &KeywordArgumentInfo{
+	Type: keywordArgumentDT,
+}
+
+// ----------------------------------------------------------------------------
+// KEYWORD ARGUMENT INFO
+// ----------------------------------------------------------------------------
+type KeywordArgumentInfo struct {
+	Type         DataType
+	TypeInstance *Instance
+}
+
+func (t *KeywordArgumentInfo) Create(name string, value *Instance) *Instance {
+	return &Instance{
+		Type: t.Type,
+		Impl: &KeywordArgumentDataImpl{
+			Name:  name,
+			Value: value,
+		},
+	}
+}
+
+func (t *KeywordArgumentInfo) Setup() {
+	t.TypeInstance = Type.Create(KeywordArgument.Type)
+	t.TypeInstance.Impl.(*TypeDataImpl).TypeInstance = t.TypeInstance
+}
+
+// ----------------------------------------------------------------------------
+// KEYWORD ARGUMENT DATA TYPE
+// ----------------------------------------------------------------------------
+type KeywordArgumentDataType struct {
+	BaseDataType
+}
+
+func (d *KeywordArgumentDataType) OnString(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return d.OnRepr(r, s, self)
+}
+
+func (d *KeywordArgumentDataType) OnRepr(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	this := self.AsKeywordArgument()
+	return String.Create(this.Name + "=" + reprOf(r, s, this.Value))
+}
+
+// ----------------------------------------------------------------------------
+// KEYWORD ARGUMENT DATA IMPL
+// ----------------------------------------------------------------------------
+type KeywordArgumentDataImpl struct {
+	Name  string
+	Value *Instance
+}
+
+// Separates the positional arguments from the keyword ones, keeping the
+// keyword arguments in the order they were given
+func splitKeywordArguments(r *Runtime, s *Scope, args []*Instance) ([]*Instance, []*KeywordArgumentDataImpl, *Instance) {
+	positional := []*Instance{}
+	keywords := []*KeywordArgumentDataImpl{}
+	names := map[string]bool{}
+
+	for _, arg := range args {
+		if !arg.IsKeywordArgument() {
+			if len(keywords) > 0 {
+				return nil, nil, r.Throw(Error.Create(s, "positional argument follows keyword argument"), s)
+			}
+
+			positional = append(positional, arg)
+			continue
+		}
+
+		keyword := arg.AsKeywordArgument()
+		if names[keyword.Name] {
+			return nil, nil, r.Throw(Error.Create(s, "duplicated keyword argument '%s'", keyword.Name), s)
+		}
+
+		names[keyword.Name] = true
+		keywords = append(keywords, keyword)
+	}
+
+	return positional, keywords, nil
+}
+
+// Reports if any of the arguments is a keyword argument
+func hasKeywordArguments(args []*Instance) bool {
+	for _, arg := range args {
+		if arg.IsKeywordArgument() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -43,6 +43,17 @@ func (t *ListInfo) Setup() {
 	t.TypeInstance.Impl.(*TypeDataImpl).TypeInstance = t.TypeInstance
 	t.Type.SetInstanceFn("push", List_Push)
 	t.Type.SetInstanceFn("pop", List_Pop)
+	t.Type.SetInstanceFn("sort", List_Sort)
+	t.Type.SetInstanceFn("sorted", List_Sorted)
+	t.Type.SetInstanceFn("insert", List_Insert)
+	t.Type.SetInstanceFn("remove", List_Remove)
+	t.Type.SetInstanceFn("indexOf", List_IndexOf)
+	t.Type.SetInstanceFn("contains", List_Contains)
+	t.Type.SetInstanceFn("reverse", List_Reverse)
+	t.Type.SetInstanceFn("extend", List_Extend)
+	t.Type.SetInstanceFn("slice", List_Slice)
+	t.Type.SetInstanceFn("binarySearch", List_BinarySearch)
+	t.Type.SetInstanceFn("copy", List_Copy)
 }
 
 // ----------------------------------------------------------------------------
@@ -60,6 +71,10 @@ func (d *ListDataType) Instantiate(r *Runtime, s *Scope, init ast.Initializer) *
 			if spread, ok := value.(*ast.SpreadOut); ok {
 				var e *Instance
 				target := r.Eval(spread.Target, s)
+				if s.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+					return target
+				}
+
 				r.ResolveIterator(target, s, func(v *Instance, err *Instance) {
 					if err != nil {
 						e = err
@@ -73,7 +88,11 @@ func (d *ListDataType) Instantiate(r *Runtime, s *Scope, init ast.Initializer) *
 				}
 				continue
 			}
-			values = append(values, r.Eval(value, s))
+			v := r.Eval(value, s)
+			if s.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+				return v
+			}
+			values = append(values, v)
 		}
 		return List.Create(values...)
 	case *ast.MapInitializer:
@@ -129,6 +148,10 @@ func (d *ListDataType) OnIter(r *Runtime, s *Scope, self *Instance, args ...*Ins
 }
 
 func (d *ListDataType) OnSet(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if self.Frozen {
+		return r.Throw(Error.FrozenValue(s, self), s)
+	}
+
 	this := self.AsList()
 	name := AsString(args[0])
 
@@ -167,6 +190,19 @@ func (t *ListDataType) OnGetItem(r *Runtime, s *Scope, self *Instance, args ...*
 	this := self.Impl.(*ListDataImpl)
 
 	nargs := len(args)
+	if nargs == 1 && args[0].IsSlice() {
+		positions, err := args[0].AsSlice().positions(len(this.Values))
+		if err != nil {
+			return r.Throw(Error.Create(s, err.Error()), s)
+		}
+
+		values := make([]*Instance, len(positions))
+		for i, pos := range positions {
+			values[i] = this.Values[pos]
+		}
+		return List.Create(values...)
+	}
+
 	if nargs > 0 && !IsNumber(args[0]) {
 		return r.Throw(Error.Create(s, "index of a list must be a number, '%s' provided", args[0].Type.GetName()), s)
 	}
@@ -180,10 +216,10 @@ func (t *ListDataType) OnGetItem(r *Runtime, s *Scope, self *Instance, args ...*
 	}
 
 	if nargs == 1 {
-		idx := AsInteger(args[0])
-		if idx >= len(this.Values) || idx < 0 {
+		idx, ok := resolveIndex(AsInteger(args[0]), len(this.Values))
+		if !ok {
 			fn := this.default_()
-			return fn.OnCall(r, s, String.Createf("list out of bounds for item '%d'", idx))
+			return fn.OnCall(r, s, String.Createf("list out of bounds for item '%d'", AsInteger(args[0])))
 		}
 		return this.Values[idx]
 	}
@@ -228,6 +264,10 @@ func (t *ListDataType) OnGetItem(r *Runtime, s *Scope, self *Instance, args ...*
 }
 
 func (t *ListDataType) OnSetItem(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if self.Frozen {
+		return r.Throw(Error.FrozenValue(s, self), s)
+	}
+
 	this := self.Impl.(*ListDataImpl)
 
 	nargs := len(args)
@@ -235,13 +275,17 @@ func (t *ListDataType) OnSetItem(r *Runtime, s *Scope, self *Instance, args ...*
 		return r.Throw(Error.Create(s, "setItem receives only one index, '%d' provided", nargs), s)
 	}
 
+	if args[0].IsSlice() {
+		return t.setSlice(r, s, self, args[0], args[1])
+	}
+
 	if !IsNumber(args[0]) {
 		return r.Throw(Error.Create(s, "index of a list must be a number, '%s' provided", args[0].Type.GetName()), s)
 	}
 
-	idx := AsInteger(args[0])
-	if idx >= len(this.Values) || idx < 0 {
-		return r.Throw(Error.Create(s, "list out of bounds for item '%d'", idx), s)
+	idx, ok := resolveIndex(AsInteger(args[0]), len(this.Values))
+	if !ok {
+		return r.Throw(Error.Create(s, "list out of bounds for item '%d'", AsInteger(args[0])), s)
 	}
 
 	this.Values[idx] = args[1]
@@ -249,20 +293,77 @@ func (t *ListDataType) OnSetItem(r *Runtime, s *Scope, self *Instance, args ...*
 	return args[1]
 }
 
+// Replaces the range selected by the slice with the values of the given
+// iterable. Slices with steps other than 1 require the same number of values.
+func (t *ListDataType) setSlice(r *Runtime, s *Scope, self *Instance, slice *Instance, value *Instance) *Instance {
+	this := self.AsList()
+	impl := slice.AsSlice()
+
+	positions, err := impl.positions(len(this.Values))
+	if err != nil {
+		return r.Throw(Error.Create(s, err.Error()), s)
+	}
+
+	if !Iterable.IsImplementedBy(value) {
+		return r.Throw(Error.Create(s, "value assigned to a slice must be iterable, '%s' provided", value.Type.GetName()), s)
+	}
+
+	values, e := collect(r, s, value)
+	if e != nil {
+		return e
+	}
+
+	if impl.step() == Boolean.FALSE || AsInteger(impl.step()) == 1 {
+		start := len(this.Values)
+		if len(positions) > 0 {
+			start = positions[0]
+		} else if impl.start() != Boolean.FALSE {
+			start = clampIndex(AsInteger(impl.start()), len(this.Values))
+		}
+		end := start + len(positions)
+
+		result := make([]*Instance, 0, len(this.Values)-len(positions)+len(values))
+		result = append(result, this.Values[:start]...)
+		result = append(result, values...)
+		result = append(result, this.Values[end:]...)
+		this.Values = result
+		return value
+	}
+
+	if len(positions) != len(values) {
+		return r.Throw(Error.Create(s, "cannot assign %d values to an extended slice of size %d", len(values), len(positions)), s)
+	}
+
+	for i, pos := range positions {
+		this.Values[pos] = values[i]
+	}
+	return value
+}
+
+func (d *ListDataType) OnEq(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return Boolean.Create(deepEqual(r, s, self, args[0], nil))
+}
+
+func (d *ListDataType) OnNeq(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return Boolean.Create(!deepEqual(r, s, self, args[0], nil))
+}
+
 func (d *ListDataType) OnString(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	return d.OnRepr(r, s, self)
 }
 
 func (d *ListDataType) OnRepr(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	r, ok := enterRepr(r, self)
+	if !ok {
+		return String.Create(cycleRepr)
+	}
+	defer leaveRepr(r, self)
+
 	list := self.Impl.(*ListDataImpl)
 
 	var values []string
 	for _, value := range list.Values {
-		if value == self {
-			values = append(values, "self")
-		} else {
-			values = append(values, value.Repr())
-		}
+		values = append(values, reprOf(r, s, value))
 	}
 
 	return String.Create("[" + strings.Join(values, ", ") + "]")
@@ -283,12 +384,20 @@ func (impl *ListDataImpl) default_() *Instance {
 //
 
 var List_Push = fn("push", p("list"), p("item", nil, true)).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if args[0].Frozen {
+		return r.Throw(Error.FrozenValue(s, args[0]), s)
+	}
+
 	this := args[0].AsList()
 	this.Values = append(this.Values, args[1:]...)
 	return Boolean.TRUE
 })
 
 var List_Pop = fn("pop", p("list"), p("index", Boolean.FALSE)).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if args[0].Frozen {
+		return r.Throw(Error.FrozenValue(s, args[0]), s)
+	}
+
 	this := args[0].AsList()
 	size := len(this.Values)
 	if size == 0 {
@@ -313,3 +422,296 @@ var List_Pop = fn("pop", p("list"), p("index", Boolean.FALSE)).as(func(r *Runtim
 
 	return item
 })
+
+var List_Sort = fn("sort", p("list"), p("key", Boolean.FALSE), p("reverse", Boolean.FALSE)).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if args[0].Frozen {
+		return r.Throw(Error.FrozenValue(s, args[0]), s)
+	}
+
+	this := args[0].AsList()
+	e := sortList(r, s, this.Values, args[1:]...)
+	if e != nil {
+		return e
+	}
+
+	return args[0]
+})
+
+var List_Sorted = fn("sorted", p("list"), p("key", Boolean.FALSE), p("reverse", Boolean.FALSE)).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	this := args[0].AsList()
+	values := make([]*Instance, len(this.Values))
+	copy(values, this.Values)
+
+	e := sortList(r, s, values, args[1:]...)
+	if e != nil {
+		return e
+	}
+
+	return List.Create(values...)
+})
+
+var List_Insert = fn("insert", p("list"), p("index"), p("item", nil, true)).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if args[0].Frozen {
+		return r.Throw(Error.FrozenValue(s, args[0]), s)
+	}
+
+	this := args[0].AsList()
+	i_index, err := arg(args, 1).IsNumber().Validate()
+	if err != nil {
+		return throw(r, s, err.Error())
+	}
+
+	index := AsInteger(i_index)
+	if index > len(this.Values) || index < 0 {
+		return throw(r, s, "list out of bounds for item '%d'", index)
+	}
+
+	values := make([]*Instance, 0, len(this.Values)+len(args)-2)
+	values = append(values, this.Values[:index]...)
+	values = append(values, args[2:]...)
+	values = append(values, this.Values[index:]...)
+	this.Values = values
+
+	return Boolean.TRUE
+})
+
+var List_Remove = fn("remove", p("list"), p("item")).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if args[0].Frozen {
+		return r.Throw(Error.FrozenValue(s, args[0]), s)
+	}
+
+	this := args[0].AsList()
+	if len(args) < 2 {
+		return throw(r, s, "remove requires the item to be removed")
+	}
+
+	index, e := indexOf(r, s, this.Values, args[1])
+	if e != nil {
+		return e
+	}
+
+	if index < 0 {
+		return Boolean.FALSE
+	}
+
+	this.Values = append(this.Values[:index], this.Values[index+1:]...)
+	return Boolean.TRUE
+})
+
+var List_IndexOf = fn("indexOf", p("list"), p("item")).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	this := args[0].AsList()
+	if len(args) < 2 {
+		return throw(r, s, "indexOf requires the item to be searched")
+	}
+
+	index, e := indexOf(r, s, this.Values, args[1])
+	if e != nil {
+		return e
+	}
+
+	return Number.Create(float64(index))
+})
+
+var List_Contains = fn("contains", p("list"), p("item")).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	this := args[0].AsList()
+	if len(args) < 2 {
+		return throw(r, s, "contains requires the item to be searched")
+	}
+
+	index, e := indexOf(r, s, this.Values, args[1])
+	if e != nil {
+		return e
+	}
+
+	return Boolean.Create(index >= 0)
+})
+
+var List_Reverse = fn("reverse", p("list")).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if args[0].Frozen {
+		return r.Throw(Error.FrozenValue(s, args[0]), s)
+	}
+
+	this := args[0].AsList()
+	for i, j := 0, len(this.Values)-1; i < j; i, j = i+1, j-1 {
+		this.Values[i], this.Values[j] = this.Values[j], this.Values[i]
+	}
+
+	return args[0]
+})
+
+var List_Extend = fn("extend", p("list"), p("items")).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if args[0].Frozen {
+		return r.Throw(Error.FrozenValue(s, args[0]), s)
+	}
+
+	this := args[0].AsList()
+	i_items, err := arg(args, 1).Validate()
+	if err != nil {
+		return throw(r, s, err.Error())
+	}
+
+	values, e := collect(r, s, i_items)
+	if e != nil {
+		return e
+	}
+
+	this.Values = append(this.Values, values...)
+	return args[0]
+})
+
+var List_Slice = fn("slice", p("list"), p("start", Number.ZERO), p("end", Boolean.FALSE)).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	this := args[0].AsList()
+	size := len(this.Values)
+
+	i_start, err := arg(args, 1).Optional(Number.ZERO).IsNumber().Validate()
+	if err != nil {
+		return throw(r, s, err.Error())
+	}
+
+	i_end, err := arg(args, 2).Optional(Number.Create(float64(size))).IsNumber().Validate()
+	if err != nil {
+		return throw(r, s, err.Error())
+	}
+
+	start := clampIndex(AsInteger(i_start), size)
+	end := clampIndex(AsInteger(i_end), size)
+	if start >= end {
+		return List.Create()
+	}
+
+	values := make([]*Instance, end-start)
+	copy(values, this.Values[start:end])
+	return List.Create(values...)
+})
+
+var List_BinarySearch = fn("binarySearch", p("list"), p("item"), p("key", Boolean.FALSE)).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	this := args[0].AsList()
+	if len(args) < 2 {
+		return throw(r, s, "binarySearch requires the item to be searched")
+	}
+
+	i_key, err := arg(args, 2).Optional(Boolean.FALSE).IsFunction().Validate()
+	if err != nil {
+		return throw(r, s, err.Error())
+	}
+
+	keyOf := func(value *Instance) *Instance {
+		if i_key == Boolean.FALSE {
+			return value
+		}
+		return i_key.OnCall(r, s, value)
+	}
+
+	target := keyOf(args[1])
+	if s.IsInterruptedAs(FlowRaise) {
+		return target
+	}
+
+	lo, hi := 0, len(this.Values)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		key := keyOf(this.Values[mid])
+		if s.IsInterruptedAs(FlowRaise) {
+			return key
+		}
+
+		lt := key.OnLt(r, s, target)
+		if s.IsInterruptedAs(FlowRaise) {
+			return lt
+		}
+
+		if AsBool(lt) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	if lo < len(this.Values) {
+		key := keyOf(this.Values[lo])
+		if s.IsInterruptedAs(FlowRaise) {
+			return key
+		}
+
+		eq := key.OnEq(r, s, target)
+		if s.IsInterruptedAs(FlowRaise) {
+			return eq
+		}
+
+		if AsBool(eq) {
+			return Number.Create(float64(lo))
+		}
+	}
+
+	return Number.Create(-1)
+})
+
+var List_Copy = fn("copy", p("list")).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	this := args[0].AsList()
+	values := make([]*Instance, len(this.Values))
+	copy(values, this.Values)
+
+	list := List.Create(values...)
+	list.AsList().Properties["default"] = this.default_()
+	return list
+})
+
+// Sorts the values in place given the optional (key, reverse) arguments
+func sortList(r *Runtime, s *Scope, values []*Instance, args ...*Instance) *Instance {
+	i_key, err := arg(args, 0).Optional(Boolean.FALSE).IsFunction().Validate()
+	if err != nil {
+		return throw(r, s, err.Error())
+	}
+
+	i_reverse, err := arg(args, 1).Optional(Boolean.FALSE).IsBoolean().Validate()
+	if err != nil {
+		return throw(r, s, err.Error())
+	}
+
+	keys := make([]*Instance, len(values))
+	for idx, value := range values {
+		if i_key == Boolean.FALSE {
+			keys[idx] = value
+			continue
+		}
+
+		keys[idx] = i_key.OnCall(r, s, value)
+		if s.IsInterruptedAs(FlowRaise) {
+			return keys[idx]
+		}
+	}
+
+	return sortInstances(r, s, values, keys, AsBool(i_reverse))
+}
+
+// Returns the position of the first value equal to the item, or -1
+func indexOf(r *Runtime, s *Scope, values []*Instance, item *Instance) (int, *Instance) {
+	for idx, value := range values {
+		eq := value.OnEq(r, s, item)
+		if s.IsInterruptedAs(FlowRaise) {
+			return -1, eq
+		}
+
+		if AsBool(eq) {
+			return idx, nil
+		}
+	}
+
+	return -1, nil
+}
+
+// Limits the index to the [0, size] range, counting negative indices from
+// the end
+func clampIndex(index, size int) int {
+	if index < 0 {
+		index += size
+	}
+
+	if index < 0 {
+		return 0
+	} else if index > size {
+		return size
+	}
+
+	return index
+}
@@ -48,6 +48,12 @@ func (t *MaybeInfo) CreateError(err *Instance) *Instance {
 func (t *MaybeInfo) Setup() {
 	t.TypeInstance = Type.Create(Maybe.Type)
 	t.TypeInstance.Impl.(*TypeDataImpl).TypeInstance = t.TypeInstance
+	t.Type.SetInstanceFn("isOk", Maybe_IsOk)
+	t.Type.SetInstanceFn("isErr", Maybe_IsErr)
+	t.Type.SetInstanceFn("unwrapOr", Maybe_UnwrapOr)
+	t.Type.SetInstanceFn("map", Maybe_Map)
+	t.Type.SetInstanceFn("andThen", Maybe_AndThen)
+	t.Type.SetInstanceFn("orElse", Maybe_OrElse)
 }
 
 // ----------------------------------------------------------------------------
@@ -57,6 +63,17 @@ type MaybeDataType struct {
 	BaseDataType
 }
 
+func (d *MaybeDataType) OnGet(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	name := AsString(args[0])
+
+	value := d.InstanceFns[name]
+	if value == nil {
+		return r.Throw(Error.NoProperty(s, d.Name, name), s)
+	}
+
+	return value
+}
+
 func (d *MaybeDataType) OnString(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	return d.OnRepr(r, s, self)
 }
@@ -78,3 +95,100 @@ type MaybeDataImpl struct {
 	Value *Instance
 	Error *Instance
 }
+
+// Calls the function with the given argument. Errors raised by the function
+// result in an error Maybe, as if the call was wrapped.
+func catchCall(r *Runtime, s *Scope, fn *Instance, arg *Instance) (*Instance, bool) {
+	ret := fn.OnCall(r, s, arg)
+	if s.IsInterruptedAs(FlowRaise) {
+		err := s.Interruption.Value
+		s.Interruption = nil
+		return Maybe.CreateError(err), false
+	}
+
+	return ret, true
+}
+
+// Wraps the value in a Maybe, unless it already is one
+func asMaybe(value *Instance) *Instance {
+	if value.IsMaybe() {
+		return value
+	}
+
+	return Maybe.Create(value)
+}
+
+var Maybe_IsOk = fn("isOk", p("maybe")).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return Boolean.Create(args[0].AsMaybe().Error == nil)
+})
+
+var Maybe_IsErr = fn("isErr", p("maybe")).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return Boolean.Create(args[0].AsMaybe().Error != nil)
+})
+
+var Maybe_UnwrapOr = fn("unwrapOr", p("maybe"), p("default")).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if len(args) < 2 {
+		return throw(r, s, "unwrapOr requires a default value")
+	}
+
+	this := args[0].AsMaybe()
+	if this.Error != nil {
+		return args[1]
+	}
+
+	return this.Value
+})
+
+// Applies the function to the value, keeping the error untouched
+var Maybe_Map = fn("map", p("maybe"), p("func")).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	i_fn, err := arg(args, 1).IsFunction().Validate()
+	if err != nil {
+		return throw(r, s, "map requires a function")
+	}
+
+	this := args[0].AsMaybe()
+	if this.Error != nil {
+		return args[0]
+	}
+
+	ret, ok := catchCall(r, s, i_fn, this.Value)
+	if !ok {
+		return ret
+	}
+
+	return Maybe.Create(ret)
+})
+
+// Chains a function returning another Maybe (or a plain value) to the value,
+// keeping the error untouched
+var Maybe_AndThen = fn("andThen", p("maybe"), p("func")).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	i_fn, err := arg(args, 1).IsFunction().Validate()
+	if err != nil {
+		return throw(r, s, "andThen requires a function")
+	}
+
+	this := args[0].AsMaybe()
+	if this.Error != nil {
+		return args[0]
+	}
+
+	ret, _ := catchCall(r, s, i_fn, this.Value)
+	return asMaybe(ret)
+})
+
+// Recovers from the error with the function result, keeping the value
+// untouched
+var Maybe_OrElse = fn("orElse", p("maybe"), p("func")).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	i_fn, err := arg(args, 1).IsFunction().Validate()
+	if err != nil {
+		return throw(r, s, "orElse requires a function")
+	}
+
+	this := args[0].AsMaybe()
+	if this.Error == nil {
+		return args[0]
+	}
+
+	ret, _ := catchCall(r, s, i_fn, this.Error)
+	return asMaybe(ret)
+})
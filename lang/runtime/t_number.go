@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"sht/lang/ast"
+	"sht/lang/runtime/meta"
 )
 
 var numberDT = &NumberDataType{
@@ -153,7 +154,7 @@ func (d *NumberDataType) OnIter(r *Runtime, s *Scope, self *Instance, args ...*I
 
 func (d *NumberDataType) OnAdd(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	if self.Type != args[0].Type {
-		return r.Throw(Error.IncompatibleTypeOperation(s, "+", self, args[0]), s)
+		return reflectOperator(r, s, meta.Add, self, args[0], Error.IncompatibleTypeOperation(s, "+", self, args[0]))
 	}
 
 	return Number.Create(AsNumber(self) + AsNumber(args[0]))
@@ -161,7 +162,7 @@ func (d *NumberDataType) OnAdd(r *Runtime, s *Scope, self *Instance, args ...*In
 
 func (d *NumberDataType) OnSub(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	if self.Type != args[0].Type {
-		return r.Throw(Error.IncompatibleTypeOperation(s, "-", self, args[0]), s)
+		return reflectOperator(r, s, meta.Sub, self, args[0], Error.IncompatibleTypeOperation(s, "-", self, args[0]))
 	}
 
 	return Number.Create(AsNumber(self) - AsNumber(args[0]))
@@ -169,7 +170,7 @@ func (d *NumberDataType) OnSub(r *Runtime, s *Scope, self *Instance, args ...*In
 
 func (d *NumberDataType) OnMul(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	if self.Type != args[0].Type {
-		return r.Throw(Error.IncompatibleTypeOperation(s, "*", self, args[0]), s)
+		return reflectOperator(r, s, meta.Mul, self, args[0], Error.IncompatibleTypeOperation(s, "*", self, args[0]))
 	}
 
 	return Number.Create(AsNumber(self) * AsNumber(args[0]))
@@ -177,7 +178,7 @@ func (d *NumberDataType) OnMul(r *Runtime, s *Scope, self *Instance, args ...*In
 
 func (d *NumberDataType) OnDiv(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	if self.Type != args[0].Type {
-		return r.Throw(Error.IncompatibleTypeOperation(s, "/", self, args[0]), s)
+		return reflectOperator(r, s, meta.Div, self, args[0], Error.IncompatibleTypeOperation(s, "/", self, args[0]))
 	}
 
 	return Number.Create(AsNumber(self) / AsNumber(args[0]))
@@ -185,7 +186,7 @@ func (d *NumberDataType) OnDiv(r *Runtime, s *Scope, self *Instance, args ...*In
 
 func (d *NumberDataType) OnIntDiv(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	if self.Type != args[0].Type {
-		return r.Throw(Error.IncompatibleTypeOperation(s, "//", self, args[0]), s)
+		return reflectOperator(r, s, meta.IntDiv, self, args[0], Error.IncompatibleTypeOperation(s, "//", self, args[0]))
 	}
 
 	return Number.Create(math.Floor(AsNumber(self) / AsNumber(args[0])))
@@ -193,7 +194,7 @@ func (d *NumberDataType) OnIntDiv(r *Runtime, s *Scope, self *Instance, args ...
 
 func (d *NumberDataType) OnMod(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	if self.Type != args[0].Type {
-		return r.Throw(Error.IncompatibleTypeOperation(s, "%", self, args[0]), s)
+		return reflectOperator(r, s, meta.Mod, self, args[0], Error.IncompatibleTypeOperation(s, "%", self, args[0]))
 	}
 
 	return Number.Create(math.Mod(AsNumber(self), AsNumber(args[0])))
@@ -201,7 +202,7 @@ func (d *NumberDataType) OnMod(r *Runtime, s *Scope, self *Instance, args ...*In
 
 func (d *NumberDataType) OnPow(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	if self.Type != args[0].Type {
-		return r.Throw(Error.IncompatibleTypeOperation(s, "**", self, args[0]), s)
+		return reflectOperator(r, s, meta.Pow, self, args[0], Error.IncompatibleTypeOperation(s, "**", self, args[0]))
 	}
 
 	return Number.Create(math.Pow(AsNumber(self), AsNumber(args[0])))
@@ -0,0 +1,146 @@
+package runtime
+
+import (
+	"sht/lang/ast"
+	"sht/lang/runtime/meta"
+	"strings"
+)
+
+// Builtin protocols, which are also implemented by the native types listed
+// in them
+var Iterable = CreateProtocolType("Iterable", nil, []*ProtocolMember{
+	{Name: string(meta.Iter), Meta: true},
+}, dictDT, iterationDT, iteratorDT, listDT, setDT, stringDT, tupleDT)
+
+var Sized = CreateProtocolType("Sized", nil, []*ProtocolMember{
+	{Name: string(meta.Len), Meta: true},
+}, dictDT, listDT, setDT, stringDT, tupleDT)
+
+var Callable = CreateProtocolType("Callable", nil, []*ProtocolMember{
+	{Name: string(meta.Call), Meta: true},
+}, functionDT, Type.Type)
+
+// ----------------------------------------------------------------------------
+// PROTOCOL DATA TYPE
+// ----------------------------------------------------------------------------
+type ProtocolType struct {
+	BaseDataType
+	Bases   []*ProtocolType
+	Members []*ProtocolMember // members declared by the protocol itself
+	Natives []DataType        // native types implementing the protocol
+}
+
+// A function required by a protocol
+type ProtocolMember struct {
+	Name     string
+	Meta     bool // declared with `on`
+	Instance bool // receives `this`, only used by regular functions
+}
+
+func (m *ProtocolMember) String() string {
+	if m.Meta {
+		return "on " + m.Name
+	}
+
+	return "fn " + m.Name
+}
+
+func CreateProtocolType(name string, bases []*ProtocolType, members []*ProtocolMember, natives ...DataType) *ProtocolType {
+	return &ProtocolType{
+		BaseDataType: BaseDataType{
+			Name:        name,
+			Properties:  map[string]ast.Node{},
+			StaticFns:   map[string]*Instance{},
+			InstanceFns: map[string]*Instance{},
+		},
+		Bases:   bases,
+		Members: members,
+		Natives: natives,
+	}
+}
+
+func (d *ProtocolType) Instantiate(r *Runtime, s *Scope, init ast.Initializer) *Instance {
+	return r.Throw(Error.Create(s, "protocol '%s' cannot be instantiated", d.Name), s)
+}
+
+// Returns the members of the protocol, including the ones of its bases
+func (d *ProtocolType) AllMembers() []*ProtocolMember {
+	members := []*ProtocolMember{}
+	for _, base := range d.Bases {
+		members = append(members, base.AllMembers()...)
+	}
+
+	return append(members, d.Members...)
+}
+
+// Returns the members of the protocol the custom type does not implement
+func (d *ProtocolType) Missing(custom *CustomType) []*ProtocolMember {
+	missing := []*ProtocolMember{}
+	seen := map[string]bool{}
+	for _, member := range d.AllMembers() {
+		if seen[member.String()] {
+			continue
+		}
+		seen[member.String()] = true
+
+		var has bool
+		switch {
+		case member.Meta:
+			has = custom.MetaFunctions[member.Name] != nil
+		case member.Instance:
+			has = custom.InstanceFns[member.Name] != nil
+		default:
+			has = custom.StaticFns[member.Name] != nil
+		}
+
+		if !has {
+			missing = append(missing, member)
+		}
+	}
+
+	return missing
+}
+
+// Reports if the value implements the protocol. Custom types implement it
+// when they have all of its members, even without declaring it.
+func (d *ProtocolType) IsImplementedBy(value *Instance) bool {
+	if custom, ok := value.Type.(*CustomType); ok {
+		return len(d.Missing(custom)) == 0
+	}
+
+	return d.implementedByNative(value.Type)
+}
+
+func (d *ProtocolType) implementedByNative(dataType DataType) bool {
+	if len(d.Members) > 0 {
+		for _, native := range d.Natives {
+			if native == dataType {
+				return true
+			}
+		}
+		return false
+	}
+
+	// protocols only combining other protocols are implemented by the
+	// native types implementing all of them
+	if len(d.Bases) == 0 {
+		return false
+	}
+
+	for _, base := range d.Bases {
+		if !base.implementedByNative(dataType) {
+			return false
+		}
+	}
+	return true
+}
+
+// Describes the missing members, such as `fn area, on iter`
+func describeMembers(members []*ProtocolMember) string {
+	names := []string{}
+	for _, member := range members {
+		names = append(names, member.String())
+	}
+
+	return strings.Join(names, ", ")
+}
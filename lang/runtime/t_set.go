@@ -0,0 +1,368 @@
+package runtime
+
+import (
+	"sht/lang/ast"
+	"sht/lang/runtime/meta"
+	"strings"
+)
+
+var setDT = &SetDataType{
+	BaseDataType: BaseDataType{
+		Name:        "Set",
+		Properties:  map[string]ast.Node{},
+		StaticFns:   map[string]*Instance{},
+		InstanceFns: map[string]*Instance{},
+	},
+}
+
+var Set = &SetInfo{
+	Type: setDT,
+}
+
+// ----------------------------------------------------------------------------
+// SET INFO
+// ----------------------------------------------------------------------------
+type SetInfo struct {
+	Type         DataType
+	TypeInstance *Instance
+}
+
+func (t *SetInfo) Create() *Instance {
+	impl := &SetDataImpl{
+		Properties: map[string]*Instance{},
+		Keys:       []string{},
+		Values:     map[string]*Instance{},
+	}
+
+	return &Instance{
+		Type: t.Type,
+		Impl: impl,
+	}
+}
+
+func (t *SetInfo) Setup() {
+	t.TypeInstance = Type.Create(Set.Type)
+	t.TypeInstance.Impl.(*TypeDataImpl).TypeInstance = t.TypeInstance
+	t.Type.SetInstanceFn("add", Set_Add)
+	t.Type.SetInstanceFn("remove", Set_Remove)
+	t.Type.SetInstanceFn("has", Set_Has)
+}
+
+// ----------------------------------------------------------------------------
+// SET DATA TYPE
+// ----------------------------------------------------------------------------
+type SetDataType struct {
+	BaseDataType
+}
+
+func (d *SetDataType) Instantiate(r *Runtime, s *Scope, init ast.Initializer) *Instance {
+	switch init.(type) {
+	case *ast.ListInitializer:
+		list := List.Type.Instantiate(r, s, init)
+		if s.IsInterruptedAs(FlowRaise) {
+			return list
+		}
+
+		set := Set.Create()
+		for _, value := range list.AsList().Values {
+			if set.AsSet().add(r, s, value); s.IsInterruptedAs(FlowRaise) {
+				return set
+			}
+		}
+		return set
+	case *ast.MapInitializer:
+		return r.Throw(Error.Create(s, "type '%s' does not allow instantiation with map initializer", d.Name), s)
+	default:
+		return Set.Create()
+	}
+}
+
+func (d *SetDataType) OnNew(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return self
+}
+
+func (d *SetDataType) OnTo(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	iter := self.AsIterator()
+	next := iter.next()
+	set := Set.Create()
+	this := set.AsSet()
+	for {
+		tion := next.OnCall(r, s, self).AsIteration()
+
+		if AsBool(tion.error()) {
+			tuple := tion.value().AsTuple()
+			return r.Throw(tuple.Values[0], s)
+
+		} else if AsBool(tion.done()) {
+			return set
+
+		} else if this.add(r, s, itemOf(tion)); s.IsInterruptedAs(FlowRaise) {
+			return set
+		}
+	}
+}
+
+func (d *SetDataType) OnIter(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	this := self.AsSet()
+	keys := make([]string, len(this.Keys))
+	copy(keys, this.Keys)
+
+	cur := 0
+	return Iterator.Create(
+		Function.CreateNative("next", []*FunctionParam{}, func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+			for cur < len(keys) {
+				cur++
+				if value, has := this.Values[keys[cur-1]]; has {
+					return Iteration.Create(value)
+				}
+			}
+
+			return Iteration.DONE
+		}),
+	)
+}
+
+func (d *SetDataType) OnGet(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	name := AsString(args[0])
+
+	value := d.InstanceFns[name]
+	if value == nil {
+		return r.Throw(Error.NoProperty(s, d.Name, name), s)
+	}
+
+	return value
+}
+
+func (d *SetDataType) OnLen(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return Number.Create(float64(len(self.AsSet().Keys)))
+}
+
+func (d *SetDataType) OnIn(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return Boolean.Create(self.AsSet().has(r, s, args[0]))
+}
+
+func (d *SetDataType) OnAdd(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if !args[0].IsSet() {
+		return reflectOperator(r, s, meta.Add, self, args[0], Error.IncompatibleTypeOperation(s, "+", self, args[0]))
+	}
+
+	result := Set.Create()
+	for _, value := range append(self.AsSet().values(), args[0].AsSet().values()...) {
+		if result.AsSet().add(r, s, value); s.IsInterruptedAs(FlowRaise) {
+			return result
+		}
+	}
+
+	return result
+}
+
+func (d *SetDataType) OnSub(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if !args[0].IsSet() {
+		return reflectOperator(r, s, meta.Sub, self, args[0], Error.IncompatibleTypeOperation(s, "-", self, args[0]))
+	}
+
+	other := args[0].AsSet()
+	result := Set.Create()
+	for _, value := range self.AsSet().values() {
+		if !other.has(r, s, value) && !s.IsInterruptedAs(FlowRaise) {
+			result.AsSet().add(r, s, value)
+		}
+	}
+
+	return result
+}
+
+func (d *SetDataType) OnMul(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if !args[0].IsSet() {
+		return reflectOperator(r, s, meta.Mul, self, args[0], Error.IncompatibleTypeOperation(s, "*", self, args[0]))
+	}
+
+	other := args[0].AsSet()
+	result := Set.Create()
+	for _, value := range self.AsSet().values() {
+		if other.has(r, s, value) {
+			result.AsSet().add(r, s, value)
+		}
+	}
+
+	return result
+}
+
+func (d *SetDataType) OnEq(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if !args[0].IsSet() {
+		return Boolean.FALSE
+	}
+
+	this := self.AsSet()
+	other := args[0].AsSet()
+	return Boolean.Create(len(this.Keys) == len(other.Keys) && this.subsetOf(r, s, other))
+}
+
+func (d *SetDataType) OnNeq(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return Boolean.Create(!AsBool(d.OnEq(r, s, self, args...)))
+}
+
+func (d *SetDataType) OnLte(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if !args[0].IsSet() {
+		return r.Throw(Error.IncompatibleTypeOperation(s, "<=", self, args[0]), s)
+	}
+
+	return Boolean.Create(self.AsSet().subsetOf(r, s, args[0].AsSet()))
+}
+
+func (d *SetDataType) OnLt(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if !args[0].IsSet() {
+		return r.Throw(Error.IncompatibleTypeOperation(s, "<", self, args[0]), s)
+	}
+
+	this := self.AsSet()
+	other := args[0].AsSet()
+	return Boolean.Create(len(this.Keys) < len(other.Keys) && this.subsetOf(r, s, other))
+}
+
+func (d *SetDataType) OnGte(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if !args[0].IsSet() {
+		return r.Throw(Error.IncompatibleTypeOperation(s, ">=", self, args[0]), s)
+	}
+
+	return Boolean.Create(args[0].AsSet().subsetOf(r, s, self.AsSet()))
+}
+
+func (d *SetDataType) OnGt(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if !args[0].IsSet() {
+		return r.Throw(Error.IncompatibleTypeOperation(s, ">", self, args[0]), s)
+	}
+
+	this := self.AsSet()
+	other := args[0].AsSet()
+	return Boolean.Create(len(this.Keys) > len(other.Keys) && other.subsetOf(r, s, this))
+}
+
+func (d *SetDataType) OnBoolean(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return Boolean.Create(len(self.AsSet().Keys) > 0)
+}
+
+func (d *SetDataType) OnString(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return d.OnRepr(r, s, self)
+}
+
+func (d *SetDataType) OnRepr(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	r, ok := enterRepr(r, self)
+	if !ok {
+		return String.Create(cycleRepr)
+	}
+	defer leaveRepr(r, self)
+
+	var values []string
+	for _, value := range self.AsSet().values() {
+		values = append(values, reprOf(r, s, value))
+	}
+
+	return String.Create("{" + strings.Join(values, ", ") + "}")
+}
+
+// ----------------------------------------------------------------------------
+// SET DATA IMPL
+// ----------------------------------------------------------------------------
+// Values are keyed by their hash key, as dict keys are. Lists, dicts and sets
+// are kept as frozen copies, so later changes don't move the values.
+type SetDataImpl struct {
+	Properties map[string]*Instance
+	Keys       []string // insertion order
+	Values     map[string]*Instance
+}
+
+func (impl *SetDataImpl) has(r *Runtime, s *Scope, value *Instance) bool {
+	_, has := hashedKey(r, s, value, impl.Values)
+	return has
+}
+
+func (impl *SetDataImpl) add(r *Runtime, s *Scope, value *Instance) bool {
+	key, has := hashedKey(r, s, value, impl.Values)
+	if has || s.IsInterruptedAs(FlowRaise) {
+		return false
+	}
+
+	impl.Keys = append(impl.Keys, key)
+	impl.Values[key] = keySnapshot(value, nil)
+	return true
+}
+
+func (impl *SetDataImpl) remove(r *Runtime, s *Scope, value *Instance) bool {
+	key, moved, has := removeHashedKey(r, s, value, impl.Values)
+	if !has {
+		return false
+	}
+
+	for i, k := range impl.Keys {
+		if k == key {
+			impl.Keys = append(impl.Keys[:i], impl.Keys[i+1:]...)
+			break
+		}
+	}
+	for i, k := range impl.Keys {
+		if k == moved {
+			impl.Keys[i] = key
+			break
+		}
+	}
+	return true
+}
+
+func (impl *SetDataImpl) values() []*Instance {
+	values := make([]*Instance, len(impl.Keys))
+	for i, key := range impl.Keys {
+		values[i] = impl.Values[key]
+	}
+	return values
+}
+
+func (impl *SetDataImpl) subsetOf(r *Runtime, s *Scope, other *SetDataImpl) bool {
+	for _, value := range impl.Values {
+		if !other.has(r, s, value) {
+			return false
+		}
+	}
+	return true
+}
+
+//
+
+var Set_Add = fn("add", p("set"), p("item", nil, true)).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if args[0].Frozen {
+		return r.Throw(Error.FrozenValue(s, args[0]), s)
+	}
+
+	this := args[0].AsSet()
+	added := false
+	for _, value := range args[1:] {
+		if this.add(r, s, value) {
+			added = true
+		}
+		if s.IsInterruptedAs(FlowRaise) {
+			return Boolean.FALSE
+		}
+	}
+
+	return Boolean.Create(added)
+})
+
+var Set_Remove = fn("remove", p("set"), p("item")).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if args[0].Frozen {
+		return r.Throw(Error.FrozenValue(s, args[0]), s)
+	}
+
+	if len(args) < 2 {
+		return throw(r, s, "remove requires the item to be removed")
+	}
+
+	return Boolean.Create(args[0].AsSet().remove(r, s, args[1]))
+})
+
+var Set_Has = fn("has", p("set"), p("item")).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if len(args) < 2 {
+		return throw(r, s, "has requires the item to be searched")
+	}
+
+	return Boolean.Create(args[0].AsSet().has(r, s, args[1]))
+})
@@ -0,0 +1,177 @@
+package runtime
+
+import (
+	"fmt"
+	"sht/lang/ast"
+)
+
+var sliceDT = &SliceDataType{
+	BaseDataType: BaseDataType{
+		Name:        "Slice",
+		Properties:  map[string]ast.Node{},
+		StaticFns:   map[string]*Instance{},
+		InstanceFns: map[string]*Instance{},
+	},
+}
+
+var Slice = &SliceInfo{
+	Type: sliceDT,
+}
+
+// ----------------------------------------------------------------------------
+// SLICE INFO
+// ----------------------------------------------------------------------------
+type SliceInfo struct {
+	Type         DataType
+	TypeInstance *Instance
+}
+
+// Creates a new slice. Omitted parts must be given as `Boolean.FALSE`.
+func (t *SliceInfo) Create(start, stop, step *Instance) *Instance {
+	return &Instance{
+		Type: t.Type,
+		Impl: &SliceDataImpl{
+			Properties: map[string]*Instance{
+				"start": start,
+				"stop":  stop,
+				"step":  step,
+			},
+		},
+	}
+}
+
+func (t *SliceInfo) Setup() {
+	t.TypeInstance = Type.Create(Slice.Type)
+	t.TypeInstance.Impl.(*TypeDataImpl).TypeInstance = t.TypeInstance
+}
+
+// ----------------------------------------------------------------------------
+// SLICE DATA TYPE
+// ----------------------------------------------------------------------------
+type SliceDataType struct {
+	BaseDataType
+}
+
+func (d *SliceDataType) OnGet(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	this := self.AsSlice()
+	name := AsString(args[0])
+
+	value, has := this.Properties[name]
+	if !has {
+		return r.Throw(Error.NoProperty(s, d.Name, name), s)
+	}
+
+	return value
+}
+
+func (d *SliceDataType) OnString(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return d.OnRepr(r, s, self)
+}
+
+func (d *SliceDataType) OnRepr(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	this := self.AsSlice()
+
+	part := func(v *Instance) string {
+		if v == Boolean.FALSE {
+			return ""
+		}
+		return reprOf(r, s, v)
+	}
+
+	repr := part(this.start()) + ":" + part(this.stop())
+	if this.step() != Boolean.FALSE {
+		repr += ":" + part(this.step())
+	}
+
+	return String.Create(repr)
+}
+
+// ----------------------------------------------------------------------------
+// SLICE DATA IMPL
+// ----------------------------------------------------------------------------
+type SliceDataImpl struct {
+	Properties map[string]*Instance
+}
+
+func (impl *SliceDataImpl) start() *Instance {
+	return impl.Properties["start"]
+}
+
+func (impl *SliceDataImpl) stop() *Instance {
+	return impl.Properties["stop"]
+}
+
+func (impl *SliceDataImpl) step() *Instance {
+	return impl.Properties["step"]
+}
+
+// Resolves the slice against a sequence of the given size, returning the
+// positions selected by it. Negative values count from the end of the
+// sequence and out of bounds values are clamped, as in python.
+func (impl *SliceDataImpl) positions(size int) ([]int, error) {
+	for _, v := range []*Instance{impl.start(), impl.stop(), impl.step()} {
+		if v != Boolean.FALSE && !v.IsNumber() {
+			return nil, fmt.Errorf("slice indices must be numbers, '%s' provided", v.Type.GetName())
+		}
+	}
+
+	step := 1
+	if impl.step() != Boolean.FALSE {
+		step = AsInteger(impl.step())
+	}
+
+	if step == 0 {
+		return nil, fmt.Errorf("slice step cannot be zero")
+	}
+
+	adjust := func(v *Instance, def int) int {
+		if v == Boolean.FALSE {
+			return def
+		}
+
+		idx := AsInteger(v)
+		if idx < 0 {
+			idx += size
+			if idx < 0 {
+				if step > 0 {
+					return 0
+				}
+				return -1
+			}
+
+		} else if idx >= size {
+			if step > 0 {
+				return size
+			}
+			return size - 1
+		}
+
+		return idx
+	}
+
+	var start, stop int
+	if step > 0 {
+		start = adjust(impl.start(), 0)
+		stop = adjust(impl.stop(), size)
+	} else {
+		start = adjust(impl.start(), size-1)
+		stop = adjust(impl.stop(), -1)
+	}
+
+	positions := []int{}
+	for i := start; (step > 0 && i < stop) || (step < 0 && i > stop); i += step {
+		positions = append(positions, i)
+	}
+
+	return positions, nil
+}
+
+// Converts a possibly negative index into a position of a sequence with the
+// given size, reporting if the position is inside the sequence
+func resolveIndex(idx, size int) (int, bool) {
+	if idx < 0 {
+		idx += size
+	}
+
+	return idx, idx >= 0 && idx < size
+}
@@ -3,6 +3,7 @@ package runtime
 import (
 	"fmt"
 	"sht/lang/ast"
+	"sht/lang/runtime/meta"
 	"strconv"
 	"strings"
 )
@@ -116,7 +117,7 @@ func (d *StringDataType) OnLen(r *Runtime, s *Scope, self *Instance, args ...*In
 
 func (d *StringDataType) OnAdd(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	if self.Type != args[0].Type {
-		return r.Throw(Error.IncompatibleTypeOperation(s, "+", self, args[0]), s)
+		return reflectOperator(r, s, meta.Add, self, args[0], Error.IncompatibleTypeOperation(s, "+", self, args[0]))
 	}
 
 	return String.Create(AsString(self) + AsString(args[0]))
@@ -208,6 +209,19 @@ func (n *StringDataType) OnGetItem(r *Runtime, s *Scope, self *Instance, args ..
 	this := AsString(self)
 
 	nargs := len(args)
+	if nargs == 1 && args[0].IsSlice() {
+		positions, err := args[0].AsSlice().positions(len(this))
+		if err != nil {
+			return r.Throw(Error.Create(s, err.Error()), s)
+		}
+
+		value := make([]byte, len(positions))
+		for i, pos := range positions {
+			value[i] = this[pos]
+		}
+		return String.Create(string(value))
+	}
+
 	if nargs > 0 && !IsNumber(args[0]) {
 		return r.Throw(Error.Create(s, "index of a string must be a number, '%s' provided", args[0].Type.GetName()), s)
 	}
@@ -220,6 +234,14 @@ func (n *StringDataType) OnGetItem(r *Runtime, s *Scope, self *Instance, args ..
 		return r.Throw(Error.Create(s, "string indexing accepts only 0, 1 or 2 parameters, %d given", nargs-1), s)
 	}
 
+	if nargs == 1 {
+		idx, ok := resolveIndex(AsInteger(args[0]), len(this))
+		if !ok {
+			return r.Throw(Error.Create(s, "string out of bounds for item '%d'", AsInteger(args[0])), s)
+		}
+		return String.Create(this[idx : idx+1])
+	}
+
 	idx0 := 0
 	if nargs >= 1 {
 		idx0 = int(AsNumber(args[0]))
@@ -0,0 +1,100 @@
+package runtime
+
+import (
+	"sht/lang/ast"
+)
+
+var superDT = &SuperDataType{
+	BaseDataType: BaseDataType{
+		Name:        "Super",
+		Properties:  map[string]ast.Node{},
+		StaticFns:   map[string]*Instance{},
+		InstanceFns: map[string]*Instance{},
+	},
+}
+
+// Super is the value of the `super` keyword, looking up the functions of the
+// types after the one declaring the running function.
+var Super = &SuperInfo{
+	Type: superDT,
+}
+
+// ----------------------------------------------------------------------------
+// SUPER INFO
+// ----------------------------------------------------------------------------
+type SuperInfo struct {
+	Type         DataType
+	TypeInstance *Instance
+}
+
+func (t *SuperInfo) Create(this *Instance, owner *CustomType) *Instance {
+	// the instance type decides the order, so sibling bases are visited
+	// before their common parents
+	mro := owner.Mro
+	if custom, ok := this.Type.(*CustomType); ok && custom.IsSubtypeOf(owner) {
+		mro = custom.Mro
+	}
+
+	types := []*CustomType{}
+	for i, t := range mro {
+		if t == owner {
+			types = mro[i+1:]
+			break
+		}
+	}
+
+	return &Instance{
+		Type: t.Type,
+		Impl: &SuperDataImpl{
+			This:  this,
+			Owner: owner,
+			Types: types,
+		},
+	}
+}
+
+func (t *SuperInfo) Setup() {
+	t.TypeInstance = Type.Create(Super.Type)
+	t.TypeInstance.Impl.(*TypeDataImpl).TypeInstance = t.TypeInstance
+}
+
+// ----------------------------------------------------------------------------
+// SUPER DATA TYPE
+// ----------------------------------------------------------------------------
+type SuperDataType struct {
+	BaseDataType
+}
+
+func (d *SuperDataType) OnGet(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	this := self.AsSuper()
+	name := AsString(args[0])
+
+	for _, t := range this.Types {
+		if fn, has := t.Declared.InstanceFns[name]; has {
+			return fn
+		}
+
+		if fn, has := t.Declared.MetaFunctions[name]; has {
+			return fn
+		}
+	}
+
+	return r.Throw(Error.Create(s, "no parent of type '%s' implements '%s'", this.Owner.Name, name), s)
+}
+
+func (d *SuperDataType) OnString(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return d.OnRepr(r, s, self)
+}
+
+func (d *SuperDataType) OnRepr(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return String.Createf("<Super:%s>", self.AsSuper().Owner.Name)
+}
+
+// ----------------------------------------------------------------------------
+// SUPER DATA IMPL
+// ----------------------------------------------------------------------------
+type SuperDataImpl struct {
+	This  *Instance
+	Owner *CustomType
+	Types []*CustomType // types searched for the parent functions, in order
+}
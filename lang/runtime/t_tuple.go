@@ -35,9 +35,17 @@ func (t *TupleInfo) Create(values ...*Instance) *Instance {
 	}
 }
 
+// Creates a tuple whose values can also be accessed by the given names
+func (t *TupleInfo) CreateNamed(names []string, values ...*Instance) *Instance {
+	tuple := t.Create(values...)
+	tuple.AsTuple().Names = names
+	return tuple
+}
+
 func (t *TupleInfo) Setup() {
 	t.TypeInstance = Type.Create(Tuple.Type)
 	t.TypeInstance.Impl.(*TypeDataImpl).TypeInstance = t.TypeInstance
+	t.Type.SetInstanceFn("toDict", Tuple_ToDict)
 }
 
 // ----------------------------------------------------------------------------
@@ -56,6 +64,10 @@ func (d *TupleDataType) Instantiate(r *Runtime, s *Scope, init ast.Initializer)
 			if spread, ok := value.(*ast.SpreadOut); ok {
 				var e *Instance
 				target := r.Eval(spread.Target, s)
+				if s.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+					return target
+				}
+
 				r.ResolveIterator(target, s, func(v *Instance, err *Instance) {
 					if err != nil {
 						e = err
@@ -70,7 +82,11 @@ func (d *TupleDataType) Instantiate(r *Runtime, s *Scope, init ast.Initializer)
 				continue
 			}
 
-			values = append(values, r.Eval(value, s))
+			v := r.Eval(value, s)
+			if s.IsInterruptedAs(FlowRaise, FlowReturn, FlowYield) {
+				return v
+			}
+			values = append(values, v)
 		}
 		return Tuple.Create(values...)
 	default:
@@ -124,16 +140,49 @@ func (d *TupleDataType) OnLen(r *Runtime, s *Scope, self *Instance, args ...*Ins
 	return Number.Create(float64(len(this.Values)))
 }
 
+func (d *TupleDataType) OnGet(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	this := self.AsTuple()
+	name := AsString(args[0])
+
+	if idx := this.indexOf(name); idx >= 0 {
+		return this.Values[idx]
+	}
+
+	if fn := d.InstanceFns[name]; fn != nil {
+		return fn
+	}
+
+	return r.Throw(Error.NoProperty(s, d.Name, name), s)
+}
+
 func (t *TupleDataType) OnGetItem(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	this := self.Impl.(*TupleDataImpl)
 
 	nargs := len(args)
+	if nargs == 1 && args[0].IsSlice() {
+		positions, err := args[0].AsSlice().positions(len(this.Values))
+		if err != nil {
+			return r.Throw(Error.Create(s, err.Error()), s)
+		}
+
+		return this.slice(positions)
+	}
+
+	if nargs == 1 && args[0].IsString() {
+		name := AsString(args[0])
+		idx := this.indexOf(name)
+		if idx < 0 {
+			return r.Throw(Error.Create(s, "tuple does not have field '%s'", name), s)
+		}
+		return this.Values[idx]
+	}
+
 	if nargs > 0 && !IsNumber(args[0]) {
-		return r.Throw(Error.Create(s, "index of a tuple must be a number, '%s' provided", args[1].Type.GetName()), s)
+		return r.Throw(Error.Create(s, "index of a tuple must be a number, '%s' provided", args[0].Type.GetName()), s)
 	}
 
 	if nargs > 1 && !IsNumber(args[1]) {
-		return r.Throw(Error.Create(s, "index of a tuple must be a number, '%s' provided", args[2].Type.GetName()), s)
+		return r.Throw(Error.Create(s, "index of a tuple must be a number, '%s' provided", args[1].Type.GetName()), s)
 	}
 
 	if nargs > 2 {
@@ -141,20 +190,24 @@ func (t *TupleDataType) OnGetItem(r *Runtime, s *Scope, self *Instance, args ...
 	}
 
 	if nargs == 1 {
-		return this.Values[AsInteger(args[0])]
+		idx, ok := resolveIndex(AsInteger(args[0]), len(this.Values))
+		if !ok {
+			return r.Throw(Error.Create(s, "tuple out of bounds for item '%d'", AsInteger(args[0])), s)
+		}
+		return this.Values[idx]
 	}
 
 	if nargs == 0 {
-		return Tuple.Create(this.Values...)
+		return Tuple.CreateNamed(this.Names, this.Values...)
 	}
 
 	size := len(this.Values)
-	idx0 := AsInteger(args[1])
+	idx0 := AsInteger(args[0])
 	if idx0 < 0 || idx0 > size-1 {
 		return r.Throw(Error.Create(s, "first index '%d' of tuple slicing out of bounds", idx0), s)
 	}
 
-	idx1 := AsInteger(args[2])
+	idx1 := AsInteger(args[1])
 	if idx1 < 0 || idx1 > size {
 		return r.Throw(Error.Create(s, "second index '%d' of tuple slicing out of bounds", idx0), s)
 	}
@@ -163,52 +216,20 @@ func (t *TupleDataType) OnGetItem(r *Runtime, s *Scope, self *Instance, args ...
 		return r.Throw(Error.Create(s, "second index '%d' of tuple slicing must be greater than the first '%d'", idx1, idx0), s)
 	}
 
-	values := make([]*Instance, 0)
-	for _, v := range this.Values[idx0:idx1] {
-		values = append(values, v)
+	positions := []int{}
+	for pos := idx0; pos < idx1; pos++ {
+		positions = append(positions, pos)
 	}
 
-	return Tuple.Create(values...)
+	return this.slice(positions)
 }
 
 func (d *TupleDataType) OnEq(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
-	if !args[0].IsTuple() {
-		return Boolean.FALSE
-	}
-
-	this := self.AsTuple()
-	other := args[0].AsTuple()
-
-	if len(this.Values) != len(other.Values) {
-		return Boolean.FALSE
-	}
-
-	for i, value := range this.Values {
-		t := value
-		o := other.Values[i]
-
-		if t.Type == WildCard.Type || o.Type == WildCard.Type {
-			continue
-		}
-
-		if t.Type != o.Type {
-			return Boolean.FALSE
-		}
-
-		if !AsBool(t.OnEq(r, s, o)) {
-			return Boolean.FALSE
-		}
-	}
-
-	return Boolean.TRUE
+	return Boolean.Create(deepEqual(r, s, self, args[0], nil))
 }
 
 func (d *TupleDataType) OnNeq(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
-	v := AsBool(d.OnEq(r, s, self, args...))
-	if v {
-		return Boolean.FALSE
-	}
-	return Boolean.TRUE
+	return Boolean.Create(!deepEqual(r, s, self, args[0], nil))
 }
 
 func (d *TupleDataType) OnString(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
@@ -216,14 +237,24 @@ func (d *TupleDataType) OnString(r *Runtime, s *Scope, self *Instance, args ...*
 }
 
 func (d *TupleDataType) OnRepr(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	r, ok := enterRepr(r, self)
+	if !ok {
+		return String.Create(cycleRepr)
+	}
+	defer leaveRepr(r, self)
+
 	tuple := self.Impl.(*TupleDataImpl)
 
 	var values []string
-	for _, value := range tuple.Values {
-		values = append(values, value.Repr())
+	for i, value := range tuple.Values {
+		if tuple.Names != nil {
+			values = append(values, tuple.Names[i]+": "+reprOf(r, s, value))
+		} else {
+			values = append(values, reprOf(r, s, value))
+		}
 	}
 
-	if len(values) == 1 {
+	if len(values) == 1 && tuple.Names == nil {
 		return String.Create("(" + values[0] + ",)")
 	} else {
 		return String.Create("(" + strings.Join(values, ", ") + ")")
@@ -236,4 +267,50 @@ func (d *TupleDataType) OnRepr(r *Runtime, s *Scope, self *Instance, args ...*In
 // ----------------------------------------------------------------------------
 type TupleDataImpl struct {
 	Values []*Instance
+	Names  []string // field names of a named tuple, nil for positional tuples
+}
+
+// Returns the position of the named field, or -1 if the tuple has none
+func (t *TupleDataImpl) indexOf(name string) int {
+	for i, n := range t.Names {
+		if n == name {
+			return i
+		}
+	}
+
+	return -1
 }
+
+// Creates a tuple with the values at the given positions, keeping their names
+func (t *TupleDataImpl) slice(positions []int) *Instance {
+	values := make([]*Instance, len(positions))
+	for i, pos := range positions {
+		values[i] = t.Values[pos]
+	}
+
+	if t.Names == nil {
+		return Tuple.Create(values...)
+	}
+
+	names := make([]string, len(positions))
+	for i, pos := range positions {
+		names[i] = t.Names[pos]
+	}
+	return Tuple.CreateNamed(names, values...)
+}
+
+// ----------------------------------------------------------------------------
+// TUPLE FUNCTIONS
+// ----------------------------------------------------------------------------
+var Tuple_ToDict = fn("toDict", p("tuple")).as(func(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	this := args[0].AsTuple()
+	if this.Names == nil {
+		return r.Throw(Error.Create(s, "only named tuples can be converted to a dict"), s)
+	}
+
+	values := map[string]*Instance{}
+	for i, name := range this.Names {
+		values[name] = this.Values[i]
+	}
+	return Dict.Create(values)
+})
@@ -65,6 +65,28 @@ func (d *TypeDataType) OnGet(r *Runtime, s *Scope, self *Instance, args ...*Inst
 	return r.Throw(Error.Create(s, "Type '%s' does not have a property '%s'", this.DataType.GetName(), name), s)
 }
 
+// Type values are equal when they represent the same data type
+func (d *TypeDataType) OnEq(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return Boolean.Create(args[0].IsType() && self.AsType().DataType == args[0].AsType().DataType)
+}
+
+func (d *TypeDataType) OnNeq(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return Boolean.Create(!AsBool(d.OnEq(r, s, self, args...)))
+}
+
+func (d *TypeDataType) OnIs(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	return Boolean.Create(isInstanceOf(args[0], self.AsType().DataType))
+}
+
+// Enums iterate over their variants
+func (d *TypeDataType) OnIter(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
+	if enum, ok := self.AsType().DataType.(*EnumType); ok {
+		return enum.iterVariants()
+	}
+
+	return d.BaseDataType.OnIter(r, s, self, args...)
+}
+
 func (d *TypeDataType) OnTo(r *Runtime, s *Scope, self *Instance, args ...*Instance) *Instance {
 	this := self.Impl.(*TypeDataImpl)
 	return this.DataType.OnTo(r, s, args[0], args[1:]...)
@@ -82,3 +104,17 @@ type TypeDataImpl struct {
 	DataType     DataType
 	TypeInstance *Instance
 }
+
+// Reports if the value is of the given type, considering the bases of the
+// custom types and the protocols they implement
+func isInstanceOf(value *Instance, dataType DataType) bool {
+	if protocol, ok := dataType.(*ProtocolType); ok {
+		return protocol.IsImplementedBy(value)
+	}
+
+	if custom, ok := value.Type.(*CustomType); ok {
+		return custom.IsSubtypeOf(dataType)
+	}
+
+	return value.Type == dataType
+}
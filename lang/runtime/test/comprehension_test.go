@@ -0,0 +1,81 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComprehension(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`List { x * 2 for x in range(4) }`, "[0, 2, 4, 6]"},
+		{`List { x for x in range(10) if x % 3 == 0 }`, "[0, 3, 6, 9]"},
+		{`List { (x, y) for x in range(3) for y in range(x) }`, "[(1, 0), (2, 0), (2, 1)]"},
+		{`List { x + y for x in range(4) if x > 1 for y in List {10, 20} if y > 10 }`, "[22, 23]"},
+		{`
+			List {
+				x ** 2
+				for x in range(5)
+				if x % 2 == 0
+			}
+		`, "[0, 4, 16]"},
+		{`List { k for k, v in Dict { a: 1 } }`, "[a]"},
+		{`List { x for x in List {} }`, "[]"},
+		{`Tuple { x for x in range(3) }`, "(0, 1, 2)"},
+		{`Set { x % 2 for x in range(5) } | to List`, "[0, 1]"},
+		{`d := Dict { x: x * x for x in range(3) }; (d[0], d[1], d[2])`, "(0, 1, 4)"},
+		{`d := Dict { k: v + 1 for k, v in Dict { a: 1, b: 2 } if v > 1 }; d['b']`, "3"},
+		{`
+			fn gen() { yield 1; yield 2; yield 3 }
+			List { x for x in gen() if x != 2 }
+		`, "[1, 3]"},
+		{`n := 10; List { x + n for x in range(2) }`, "[10, 11]"},
+		{`x := 'outer'; List { x for x in range(2) }; x`, "outer"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestComprehensionMatchesPipe(t *testing.T) {
+	cases := []struct{ comprehension, pipe string }{
+		{`List { x * 2 for x in range(5) }`, `range(5) | map x: x * 2 | to List`},
+		{`List { x for x in range(10) if x % 2 == 0 }`, `range(10) | filter x: x % 2 == 0 | to List`},
+		{`List { x * 10 for x in range(10) if x > 5 }`, `range(10) | filter x: x > 5 | map x: x * 10 | to List`},
+		{`Tuple { x for x in range(4) }`, `range(4) | to Tuple`},
+		{`List { (index: i, value: v) for i, v in List {'a', 'b'} | enumerate }`, `List {'a', 'b'} | enumerate | to List`},
+	}
+
+	for _, c := range cases {
+		expected, err := lang.Eval([]byte(c.pipe))
+		assert.NoError(t, err)
+
+		result, err := lang.Eval([]byte(c.comprehension))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	}
+}
+
+func TestComprehensionErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`fn g() { yield 1; raise 'boom' }; List { x for x in g() }`, "boom"},
+		{`List { x / y for x in range(2) }`, "y"},
+		{`List { 1, x for x in range(2) }`, "comprehensions cannot be mixed"},
+		{`List { x for x range(2) }`, "expecting 'in' token"},
+		{`data P { a = 1 }; P { a: x for x in range(2) }`, "comprehension"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package test
+
+import (
+	"bytes"
+	"sht/lang"
+	"sht/lang/runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecorators(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`
+			calls := List {}
+			@memoize
+			fn fib(n) {
+				calls.push(n)
+				if n < 2 { return n }
+				return fib(n - 1) + fib(n - 2)
+			}
+			(fib(30), len(calls))
+		`, "(832040, 31)"},
+		{`
+			calls := 0
+			@memoize
+			fn size(l) {
+				calls += 1
+				len(l)
+			}
+			l := List {1, 2}
+			a := size(l)
+			l.push(3)
+			(a, size(l), size(List {1, 2}), calls)
+		`, "(2, 3, 2, 2)"},
+		{`
+			data P {
+				x = 0
+				on hash(this) { return 0 }
+				on eq(this, other) { return this.x == other.x }
+			}
+			calls := 0
+			@memoize
+			fn f(p) { calls += 1; p.x }
+			(f(P { x: 1 }), f(P { x: 2 }), f(P { x: 1 }), calls)
+		`, "(1, 2, 1, 2)"},
+		{`
+			calls := 0
+			@memoize
+			fn add(a, b) { calls += 1; a + b }
+			(add(1, b=2), add(1, b=2), add(b=2, a=1), add(1, 2), add(a=2, b=1), calls)
+		`, "(3, 3, 3, 3, 3, 4)"},
+		{`
+			fn double(f) { return (a) => f(a) * 2 }
+			fn inc(f) { return (a) => f(a) + 1 }
+			@double
+			@inc
+			fn id(a) { a }
+			id(5)
+		`, "12"},
+		{`
+			fn times(n) { return (f) => (a) => f(a) * n }
+			@times(3) fn id(a) { a }
+			id(5)
+		`, "15"},
+		{`
+			@trace
+			fn add(a, b) { a + b }
+			add(1, b=2)
+		`, "3"},
+		{`
+			@deprecated('use add')
+			fn plus(a, b) { a + b }
+			plus(1, 2) + plus(b=3, a=1)
+		`, "7"},
+		{`
+			data P {
+				x = 1
+				@memoize
+				fn get(this, y) { this.x + y }
+				@trace
+				on add(this, other) { this.x + other }
+			}
+			p := P()
+			(p.get(1), p + 2)
+		`, "(2, 3)"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestTrace(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`
+			@trace
+			fn add(a, b) { a + b }
+			add(1, 2)
+		`, "add(1, 2)\nadd(1, 2) -> 3\n"},
+		{`
+			@trace
+			fn fact(n) { if n < 2 { return 1 }; n * fact(n - 1) }
+			fact(2)
+		`, "fact(2)\n  fact(1)\n  fact(1) -> 1\nfact(2) -> 2\n"},
+	}
+
+	for _, c := range cases {
+		tree, err := lang.Parse([]byte(c.input))
+		assert.NoError(t, err)
+
+		output := &bytes.Buffer{}
+		r := runtime.CreateRuntime()
+		r.TraceOutput = output
+		_, err = r.Run(tree)
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, output.String())
+	}
+}
+
+func TestDeprecated(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`
+			@deprecated
+			fn plus(a, b) { a + b }
+			plus(1, 2) + plus(3, 4)
+		`, "warning: 'plus' is deprecated\n"},
+		{`
+			@deprecated('use add')
+			fn plus(a, b) { a + b }
+			plus(1, 2)
+		`, "warning: 'plus' is deprecated: use add\n"},
+	}
+
+	for _, c := range cases {
+		tree, err := lang.Parse([]byte(c.input))
+		assert.NoError(t, err)
+
+		output := &bytes.Buffer{}
+		r := runtime.CreateRuntime()
+		r.WarningOutput = output
+		_, err = r.Run(tree)
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, output.String())
+	}
+}
+
+func TestDecoratorErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`@1 fn f() {}`, "decorator must be callable, 'Number' given"},
+		{`fn bad(f) { 1 }; @bad fn f() {}`, "decorator must return a callable, 'Number' returned"},
+		{`@memoize x := 1`, "decorators must be followed by a function definition"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const resourceType = `
+log := List {}
+data Res {
+	name = ''
+	on close(this) { log.push('close ' .. this.name) }
+}
+`
+
+func TestDefer(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`
+			fn f() {
+				defer log.push(1)
+				defer { log.push(2) }
+				log.push(0)
+			}
+			f()
+			log
+		`, "[0, 2, 1]"},
+		{`
+			fn f() {
+				defer log.push('deferred')
+				return log.push('returned')
+			}
+			f()
+			log
+		`, "[returned, deferred]"},
+		{`
+			fn f() {
+				defer log.push('deferred')
+				raise 'boom'
+			}
+			f()?
+			log
+		`, "[deferred]"},
+		{`
+			fn f() {
+				defer log.push('deferred')
+				raise 'boom'
+			}
+			f()?.isErr()
+		`, "true"},
+		{`fn f() { defer raise 'in defer'; return 1 }; f()?.isErr()`, "true"},
+		{`
+			pipe range(3) as i {
+				defer log.push('end ' .. i)
+				if i == 1 { break }
+				log.push(i)
+			}
+			log
+		`, "[0, end 0, end 1]"},
+		{`
+			{
+				defer log.push('outer')
+				{
+					defer log.push('inner')
+				}
+				log.push('body')
+			}
+			log
+		`, "[inner, body, outer]"},
+		{`
+			fn gen() {
+				defer log.push('cleanup')
+				pipe range(10) as i { yield i }
+			}
+			it := gen()
+			it.next()
+			log.push('running')
+			it.close()
+			log
+		`, "[running, cleanup]"},
+		{`
+			fn gen() {
+				defer log.push('cleanup')
+				yield 1
+				yield 2
+			}
+			values := gen() | to List
+			(values, log)
+		`, "([1, 2], [cleanup])"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(resourceType + c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestWith(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`
+			with r := (Res { name: 'a' }) {
+				log.push('using ' .. r.name)
+			}
+			log
+		`, "[using a, close a]"},
+		{`with (Res { name: 'a' }) { log.push('body') }; log`, "[body, close a]"},
+		{`
+			fn f() {
+				with r := (Res { name: 'a' }) {
+					raise 'boom'
+				}
+			}
+			f()?
+			log
+		`, "[close a]"},
+		{`
+			fn f() {
+				with r := (Res { name: 'a' }) {
+					return 'returned'
+				}
+			}
+			(f(), log)
+		`, "(returned, [close a])"},
+		{`
+			fn gen() {
+				with r := (Res { name: 'a' }) {
+					yield 1
+					yield 2
+				}
+			}
+			values := gen() | to List
+			(values, log)
+		`, "([1, 2], [close a])"},
+		{`
+			fn gen() {
+				with r := (Res { name: 'a' }) {
+					yield 1
+					yield 2
+				}
+			}
+			it := gen()
+			it.next()
+			log.push('suspended')
+			it.close()
+			log
+		`, "[suspended, close a]"},
+		{`
+			fn gen() {
+				defer log.push('gen closed')
+				yield 1
+			}
+			with it := gen() {
+				it.next()
+			}
+			log
+		`, "[gen closed]"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(resourceType + c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestWithErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`with x := 1 { x }`, "does not implement action 'close'"},
+		{`with x = 1 { x }`, "expected ':=' in with expression"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(resourceType + c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDestructuring(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`a, (b, c) := (1, (2, 3)); (a, b, c)`, "(1, 2, 3)"},
+		{`a, ...b, c := List {1, 2, 3, 4}; (a, b, c)`, "(1, [2, 3], 4)"},
+		{`a, (b, ...c) := (1, List {2, 3, 4}); (a, b, c)`, "(1, 2, [3, 4])"},
+		{`a, ...b := range(4); (a, b)`, "(0, [1, 2, 3])"},
+		{`a, b := Set {1, 2}; (a, b)`, "(1, 2)"},
+		{`{a, b} := Dict {a: 1, b: 2, c: 3}; (a, b)`, "(1, 2)"},
+		{`{a, ...rest} := Dict {a: 1, b: 2}; (a, rest)`, "(1, {b: 2})"},
+		{`
+			data Point {
+				x = 0
+				y = 0
+			}
+			{x, y} := Point { x: 1, y: 2 }
+			(x, y)
+		`, "(1, 2)"},
+		{`fn f((a, b), {c}) { a + b + c }; f((1, 2), Dict {c: 3})`, "6"},
+		{`f := ({a, b}, c) => (a + b)*c; f(Dict {a: 1, b: 2}, 3)`, "9"},
+		{`List { Dict {a: 1}, Dict {a: 2} } | map {a}: a*10`, "[10, 20]"},
+		{`
+			r := List {}
+			pipe Dict {a: 1} as k, v { r.push((k, v)) }
+			r
+		`, "[(a, 1)]"},
+		{`
+			r := List {}
+			pipe List {(1, 2), (3, 4)} as (a, b) { r.push(a + b) }
+			r
+		`, "[3, 7]"},
+		{`
+			r := List {}
+			pipe List {Dict {a: 1}} as {a} { r.push(a) }
+			r
+		`, "[1]"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestDestructuringErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`a, b := (1, 2, 3)`, "assignment right side has more elements than left side"},
+		{`a, (b, c) := (1, (2,))`, "assignment right side has less elements than left side"},
+		{`{a} := Dict {b: 1}`, "key 'a' not found in dict"},
+		{`{...a} := List {1}`, "cannot spread the fields of type 'List'"},
+		{`fn f((a, b)) { a }; f()`, "missing arguments for destructured parameter 1"},
+		{`(a, (b, c)) := (1, 2)`, "cannot destructure a value of type 'Number' into the pattern (b, c)"},
+		{`a, (b, ...c) := List {1, Dict {}}`, "cannot destructure a value of type 'Dict' into the pattern (b, ...c)"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
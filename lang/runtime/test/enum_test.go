@@ -0,0 +1,150 @@
+package test
+
+import (
+	"bytes"
+	"sht/lang"
+	"sht/lang/runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnum(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`
+			enum Shape { Circle(r), Rect(w, h), Empty }
+			(Shape.Circle(3), Shape.Rect(1, 2), Shape.Empty)
+		`, "(Shape.Circle(3), Shape.Rect(1, 2), Shape.Empty)"},
+		{`
+			enum Shape {
+				Circle(r)
+				Rect(w, h)
+			}
+			c := Shape.Circle(2)
+			(c.r, Shape.Rect(4, 5).h)
+		`, "(2, 5)"},
+		{`
+			enum Shape { Circle(r), Empty }
+			(Shape.Circle(1) == Shape.Circle(1), Shape.Circle(1) == Shape.Circle(2), Shape.Empty == Shape.Empty, Shape.Empty != Shape.Circle(1))
+		`, "(true, false, true, true)"},
+		{`
+			enum Color { Red, Green, Blue }
+			Color | to List
+		`, "[Color.Red, Color.Green, Color.Blue]"},
+		{`
+			enum Shape { Circle(r), Empty }
+			variants := Shape | to List
+			(variants[0](2), variants[1])
+		`, "(Shape.Circle(2), Shape.Empty)"},
+		{`
+			enum Shape { Circle(r), Empty }
+			(Shape.Empty is Shape, 1 is Shape, Shape.Circle(1) is Shape, Shape)
+		`, "(true, false, true, <Type:Shape>)"},
+		{`
+			enum Shape { Circle(r), Rect(w, h), Empty }
+			area := s => match s {
+				Circle(r): 3 * r ** 2
+				Shape.Rect(w, h): w * h
+				Empty: 0
+			}
+			(area(Shape.Circle(2)), area(Shape.Rect(2, 3)), area(Shape.Empty))
+		`, "(12, 6, 0)"},
+		{`
+			enum Shape { Circle(r), Rect(w, h) }
+			describe := s => match s {
+				Rect(1, _): 'thin'
+				Rect(w, w): 'square'
+				Rect(_, _): 'rect'
+				Circle(r): 'circle'
+			}
+			(describe(Shape.Rect(1, 5)), describe(Shape.Rect(2, 4)), describe(Shape.Circle(1)))
+		`, "(thin, rect, circle)"},
+		{`
+			enum Option { Some(value), None }
+			enum Shape { Circle(r), Empty }
+			describe := o => match o {
+				Some(Circle(r)): r
+				Some(Empty): 'empty'
+				None: 'none'
+				_: 'other'
+			}
+			(describe(Option.Some(Shape.Circle(4))), describe(Option.Some(Shape.Empty)), describe(Option.None))
+		`, "(4, empty, none)"},
+		{`
+			enum Option { Some(value), None }
+			enum Shape { Circle(r) }
+			describe := o => match o {
+				Some(Shape.Circle(r)): r
+				Some(Circle(r)): r
+				_: 'other'
+			}
+			describe(Option.Some(1))
+		`, "other"},
+		{`
+			enum Shape { Circle(r), Empty }
+			match Shape.Empty {
+				Circle(r): 'circle'
+			}
+		`, "false"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestEnumWarnings(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`
+			enum Shape { Circle(r), Rect(w, h), Empty }
+			match Shape.Empty {
+				Circle(r): 'circle'
+			}
+		`, "warning: match on 'Shape' does not cover the variants Rect, Empty\n"},
+		{`
+			enum Shape { Circle(r), Empty }
+			match Shape.Empty {
+				Circle(r): 'circle'
+				_: 'other'
+			}
+		`, ""},
+	}
+
+	for _, c := range cases {
+		tree, err := lang.Parse([]byte(c.input))
+		assert.NoError(t, err)
+
+		output := &bytes.Buffer{}
+		r := runtime.CreateRuntime()
+		r.WarningOutput = output
+		_, err = r.Run(tree)
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, output.String())
+	}
+}
+
+func TestEnumErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`enum Shape { Circle(r) }; Shape.Circle(1, 2)`, "variant 'Shape.Circle' expects 1 values, 2 given"},
+		{`enum Shape { Circle(r) }; Shape.Circle(1).w`, "w"},
+		{`enum Shape { Circle(r) }; Shape.Square`, "does not have a property 'Square'"},
+		{`enum Shape { Circle(r), Circle }`, "already defined"},
+		{`enum Shape { Rect(w, w) }`, "already defined"},
+		{`enum Shape { Empty }; Shape {}`, "enum 'Shape' cannot be instantiated"},
+		{`enum Shape { Circle(r) }; match Shape.Circle(1) { Circle(a, b): 1 }`, "variant 'Shape.Circle' has 1 values, 2 given in the pattern"},
+		{`enum Shape { 1 }`, "invalid enum variant '1'"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
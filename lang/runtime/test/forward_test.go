@@ -0,0 +1,62 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForward(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`fn double(x) { x * 2 }; 3 |> double |> double`, "12"},
+		{`'abc' |> len`, "3"},
+		{`5 |> x: x + 1`, "6"},
+		{`(1, 2) |> a, b: a + b`, "3"},
+		{`fn add(a, b) { a + b }; 1 |> add(2)`, "3"},
+		{`fn sub(a, b) { a - b }; 1 |> sub(_, 10)`, "-9"},
+		{`fn sub(a, b) { a - b }; 1 |> sub(10, _)`, "9"},
+		{`fn apply(v, f) { f(v) }; 3 |> apply x: x * 10`, "30"},
+		{`'3' |> Number`, "3"},
+		{`List {3, 1, 2} |> List.sorted`, "[1, 2, 3]"},
+		{`range(5) | map x: x * 2 |> len`, "5"},
+		{`range(3) |> iter | map x: x + 1`, "[1, 2, 3]"},
+		{`
+			List {1, 2, 3}
+				| map x: x * 2
+				|> len
+				|> x: x + 1
+		`, "4"},
+		{`
+			data P {
+				on call(this, a) { a * 3 }
+			}
+			p := P()
+			2 |> p
+		`, "6"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestForwardErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`x := 1; 2 |> x`, "cannot forward value to non-callable type 'Number'"},
+		{`x := 1; 2 |> x(1)`, "cannot forward value to non-callable type 'Number'"},
+		{`2 |> undefinedFn`, "trying to use an unidentified variable 'undefinedFn'"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeze(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`a := freeze(List {1, 2}); (a, isFrozen(a), isFrozen(List {}))`, "([1, 2], true, false)"},
+		{`(isFrozen(1), isFrozen('a'), isFrozen((1, 2)), isFrozen(Dict {}), isFrozen(print))`, "(true, true, true, false, false)"},
+		{`
+			a := freeze(List {List {1}})
+			a[0].push(2)
+			a
+		`, "[[1, 2]]"},
+		{`
+			a := freeze(List {List {1}, Dict {b: Set {1}}}, true)
+			(isFrozen(a[0]), isFrozen(a[1]), isFrozen(a[1]['b']))
+		`, "(true, true, true)"},
+		{`
+			a := List {1}
+			a.push(a)
+			freeze(a, true)
+			isFrozen(a)
+		`, "true"},
+		{`
+			d := Dict {a: 1}
+			d.default = v => 0
+			freeze(d)
+			(d['x'], len(d))
+		`, "(0, 1)"},
+		{`
+			a := freeze(List {1, List {2}}, true)
+			b := copy(a)
+			c := deepCopy(a)
+			b.push(3)
+			c[1].push(3)
+			(isFrozen(a), b, c)
+		`, "(true, [1, [2], 3], [1, [2, 3]])"},
+		{`
+			data Point {
+				x = 0
+				y = 0
+			}
+			d := Dict {}
+			d[freeze(Point { x: 1, y: 2 })] = 'a'
+			(d[freeze(Point { x: 1, y: 2 })], len(d), hash(freeze(Point { x: 1 })) == hash(freeze(Point { x: 1 })))
+		`, "(a, 1, true)"},
+		{`
+			data Point { x = 0 }
+			p := freeze(Point { x: 1 })
+			(p.x, isFrozen(p), getField(p, 'x'))
+		`, "(1, true, 1)"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestFreezeErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`a := freeze(List {1}); a.push(2)`, "cannot modify frozen value of type 'List'"},
+		{`a := freeze(List {1}); a.pop()`, "cannot modify frozen value of type 'List'"},
+		{`a := freeze(List {1}); a[0] = 2`, "cannot modify frozen value of type 'List'"},
+		{`a := freeze(List {1, 2}); a[0:1] = List {3}`, "cannot modify frozen value of type 'List'"},
+		{`a := freeze(List {2, 1}); a.sort()`, "cannot modify frozen value of type 'List'"},
+		{`a := freeze(List {1}); a.insert(0, 2)`, "cannot modify frozen value of type 'List'"},
+		{`a := freeze(List {1}); a.remove(1)`, "cannot modify frozen value of type 'List'"},
+		{`a := freeze(List {1}); a.reverse()`, "cannot modify frozen value of type 'List'"},
+		{`a := freeze(List {1, 2}); random.shuffle(a)`, "cannot modify frozen value of type 'List'"},
+		{`a := freeze(List {1}); a.extend(List {2})`, "cannot modify frozen value of type 'List'"},
+		{`a := freeze(List {1}); a.default = 2`, "cannot modify frozen value of type 'List'"},
+		{`d := freeze(Dict {a: 1}); d['b'] = 2`, "cannot modify frozen value of type 'Dict'"},
+		{`d := freeze(Dict {a: 1}); d.default = 2`, "cannot modify frozen value of type 'Dict'"},
+		{`s := freeze(Set {1}); s.add(2)`, "cannot modify frozen value of type 'Set'"},
+		{`s := freeze(Set {1}); s.remove(1)`, "cannot modify frozen value of type 'Set'"},
+		{`data P { x = 0 }; p := freeze(P {}); p.x = 1`, "cannot modify frozen value of type 'P'"},
+		{`data P { x = 0 }; p := freeze(P {}); setField(p, 'x', 1)`, "cannot modify frozen value of type 'P'"},
+		{`a := freeze(List {List {1}}, true); a[0].push(2)`, "cannot modify frozen value of type 'List'"},
+		{`freeze(List {}, 1)`, "Expecting argument at index '1' to be a 'Boolean', got 'Number'"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
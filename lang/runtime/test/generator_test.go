@@ -0,0 +1,172 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`fn g() { yield 1; yield 2 }; g() | to List`, "[1, 2]"},
+		{`
+			fn g() {
+				x := yield 1
+				yield x * 2
+			}
+			it := g()
+			it.next()
+			it.send(21).value
+		`, "(42,)"},
+		{`
+			fn acc() {
+				total := 0
+				for {
+					total += yield total
+				}
+			}
+			a := acc()
+			a.next()
+			a.send(1)
+			a.send(2)
+			a.send(3).value
+		`, "(6,)"},
+		{`
+			fn g() {
+				pipe range(2) as i {
+					x := yield i
+					yield x
+				}
+			}
+			it := g()
+			it.next()
+			(it.send('a').value, it.next().value, it.send('b').value)
+		`, "((a,), (1,), (b,))"},
+		{`fn g() { x := yield 1; yield x }; g() | to List`, "[1, false]"},
+		{`
+			fn g() {
+				yield 1
+				return 'end'
+			}
+			it := g()
+			it | to List
+			(it.done, it.result)
+		`, "(true, end)"},
+		{`
+			fn inner() {
+				yield 1
+				yield 2
+				return 3
+			}
+			fn outer() {
+				r := yield from inner()
+				yield r * 10
+			}
+			outer() | to List
+		`, "[1, 2, 30]"},
+		{`fn g() { yield from List {1, 2}; yield from range(2) }; g() | to List`, "[1, 2, 0, 1]"},
+		{`
+			fn inner() {
+				x := yield 1
+				yield x
+			}
+			fn outer() { yield from inner() }
+			it := outer()
+			it.next()
+			it.send('sent').value
+		`, "(sent,)"},
+		{`
+			fn g() {
+				pipe range(10) as i {
+					yield i
+				}
+				return 'finished'
+			}
+			it := g()
+			it.next()
+			(it.close(), it.done, it.next())
+		`, "(false, true, <Iteration:done>)"},
+		{`
+			fn g() { yield 1; return 2 }
+			it := g()
+			it | to List
+			it.close()
+		`, "2"},
+		{`
+			c := List {}
+			fn g() {
+				r := (c.push(1), yield 5)
+				yield r
+			}
+			l := g() | to List
+			(l, c)
+		`, "([5, (true, false)], [1])"},
+		{`
+			fn g() { yield (1, yield 2) }
+			it := g()
+			(it.next().value, it.send(5).value)
+		`, "((2,), ((1, 5),))"},
+		{`
+			fn h() {
+				if (yield 1) { yield 2 }
+				yield 3
+			}
+			it := h()
+			(it.next().value, it.send(true).value, it.next().value, h() | to List)
+		`, "((1,), (2,), (3,), [1, 3])"},
+		{`
+			calls := List {}
+			fn side() { calls.push(1); 10 }
+			fn g() {
+				x := side() + (yield 1)
+				yield x
+			}
+			it := g()
+			(it.next().value, it.send(5).value, len(calls))
+		`, "((1,), (15,), 1)"},
+		{`
+			fn g() { yield (yield 1) + (yield 2) }
+			it := g()
+			(it.next().value, it.send(10).value, it.send(20).value)
+		`, "((1,), (2,), (30,))"},
+		{`
+			fn add(a, b) { a + b }
+			fn g() { yield add(1, yield 2) }
+			it := g()
+			(it.next().value, it.send(5).value)
+		`, "((2,), (6,))"},
+		{`
+			fn g() {
+				pipe range(2) as i {
+					yield (i, yield i * 10)
+				}
+			}
+			g() | to List
+		`, "[0, (0, false), 10, (1, false)]"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestGeneratorErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`iter(List {1}).send(1)`, "send is only available for generators"},
+		{`fn g() { yield 1 }; g().send()`, "send requires the value to be sent"},
+		{`fn inner() { yield 1; raise 'boom' }; fn g() { yield from inner() }; g() | to List`, "boom"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
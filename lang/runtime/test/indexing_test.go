@@ -0,0 +1,69 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexing(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`List { 1, 2, 3 }[-1]`, "3"},
+		{`List { 1, 2, 3 }[0, 2]`, "[1, 2]"},
+		{`List { 1, 2, 3, 4, 5 }[1:3]`, "[2, 3]"},
+		{`List { 1, 2, 3, 4, 5 }[:-1]`, "[1, 2, 3, 4]"},
+		{`List { 1, 2, 3, 4, 5 }[-2:]`, "[4, 5]"},
+		{`List { 1, 2, 3, 4, 5 }[::2]`, "[1, 3, 5]"},
+		{`List { 1, 2, 3, 4, 5 }[::-1]`, "[5, 4, 3, 2, 1]"},
+		{`List { 1, 2, 3 }[10:]`, "[]"},
+		{`'hello'[-1]`, "o"},
+		{`'hello'[1:]`, "ello"},
+		{`'hello'[::-1]`, "olleh"},
+		{`(1, 2, 3)[-1]`, "3"},
+		{`(1, 2, 3)[1:]`, "(2, 3)"},
+		{`a := List { 1, 2 }; a[-1] = 5; a`, "[1, 5]"},
+		{`a := List { 1, 2, 3, 4 }; a[1:3] = List { 9 }; a`, "[1, 9, 4]"},
+		{`a := List { 1, 2 }; a[2:] = List { 3, 4 }; a`, "[1, 2, 3, 4]"},
+		{`a := List { 1, 2, 3, 4 }; a[::2] = List { 7, 8 }; a`, "[7, 2, 8, 4]"},
+		{`
+			data X {
+				on getItem(this, i) { (i.start, i.stop, i.step) }
+			}
+			X()[:2]
+		`, "(false, 2, false)"},
+		{`
+			data X {
+				on getItem(this, i) { i }
+			}
+			String(X()[1:2:3])
+		`, "1:2:3"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestIndexingErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`List { 1, 2, 3 }[-10]`, "list out of bounds for item '-10'"},
+		{`'abc'[-10]`, "string out of bounds for item '-10'"},
+		{`'abc'[3]`, "string out of bounds for item '3'"},
+		{`(1, 2, 3)[-10]`, "tuple out of bounds for item '-10'"},
+		{`a := List { 1, 2 }; a[0:1] = 5`, "value assigned to a slice must be iterable, 'Number' provided"},
+		{`a := List { 1, 2 }; a[::2] = true`, "value assigned to a slice must be iterable, 'Boolean' provided"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
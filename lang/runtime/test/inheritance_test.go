@@ -0,0 +1,108 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInheritance(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`
+			data User {
+				name = 'anonymous'
+				fn greet(this) { return 'hi ' .. this.name }
+			}
+			data Admin like User {
+				level = 1
+				fn greet(this) { return super.greet() .. '!' }
+			}
+			a := Admin { name: 'bob' }
+			(a.greet(), a.level, User { name: 'ann' }.greet())
+		`, "(hi bob!, 1, hi ann)"},
+		{`
+			data User { name = 'anonymous' }
+			data Admin like User {}
+			a := Admin {}
+			(a is Admin, a is User, User {} is Admin, isInstance(a, User), type(a) == User)
+		`, "(true, true, false, true, false)"},
+		{`(1 is Number, 1 is String, 'a' is String)`, "(true, false, true)"},
+		{`
+			data User {
+				name = 'anonymous'
+				on string(this) { return 'User ' .. this.name }
+			}
+			data Admin like User {
+				on string(this) { return 'Admin/' .. super.string() }
+			}
+			'' .. Admin { name: 'bob' }
+		`, "Admin/User bob"},
+		{`
+			data A { fn who(this) { return 'A' } }
+			data B like A { fn who(this) { return 'B>' .. super.who() } }
+			data C like A { fn who(this) { return 'C>' .. super.who() } }
+			data D like B, C { fn who(this) { return 'D>' .. super.who() } }
+			(D {}.who(), B {}.who())
+		`, "(D>B>C>A, B>A)"},
+		{`
+			data A { fn who(this) { return 'A' } }
+			data B { fn who(this) { return 'B' } }
+			data C like A, B {}
+			data D like B, A {}
+			(C {}.who(), D {}.who())
+		`, "(A, B)"},
+		{`
+			data A { x = 'a' }
+			data B like A {}
+			data C like A { x = 'c' }
+			data D like B, C {}
+			D {}.x
+		`, "c"},
+		{`
+			data A { fn f(this) { return 'A' } }
+			data B like A {}
+			data C like B {
+				fn f(this) {
+					g := () => super.f()
+					return g() .. 'C'
+				}
+			}
+			C {}.f()
+		`, "AC"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestInheritanceErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`super.greet()`, "super can only be used inside data functions"},
+		{`data A { fn f(this) { return super.f() } }; A {}.f()`, "no parent of type 'A' implements 'f'"},
+		{`
+			data A { fn create() { return super.create() } }
+			A.create()
+		`, "super requires an instance"},
+		{`
+			data A {}
+			data B like A {}
+			data C like A, B {}
+		`, "cannot create a consistent resolution order for type 'C'"},
+		{`data A like Unknown {}`, "cannot find type 'Unknown'"},
+		{`x := 1; data A like x {}`, "type 'x' is not custom data"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeywordArguments(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`fn foo(a=1, b=1, c=2) { (a + b)*c }; foo(c=5)`, "10"},
+		{`fn foo(a=1, b=1, c=2) { (a, b, c) }; foo(3, c=5)`, "(3, 1, 5)"},
+		{`fn foo(a, b) { (a, b) }; foo(b=1, a=2)`, "(2, 1)"},
+		{`fn foo(a, ...rest) { (a, rest) }; foo(1, 2, 3)`, "(1, [2, 3])"},
+		{`fn foo(a, ...rest, b) { (a, rest, b) }; foo(1, 2, 3, b=4)`, "(1, [2, 3], 4)"},
+		{`fn foo(a, ...rest, b) { (a, rest, b) }; foo(1, 2, 3)`, "(1, [2], 3)"},
+		{`random.int(max=3, min=3)`, "3"},
+		{`range(10) | take(amount=2)`, "[0, 1]"},
+		{`List { 3, 1, 2 }.sorted(reverse=true)`, "[3, 2, 1]"},
+		{`
+			data P {
+				x = 0
+				on new(this, x=1, y=2) { this.x = x + y }
+			}
+			P(y=10).x
+		`, "11"},
+		{`
+			data P {
+				on call(this, a, b=0) { a - b }
+			}
+			p := P()
+			p(b=1, a=5)
+		`, "4"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestKeywordArgumentErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`fn foo(a, b) { (a, b) }; foo(1, a=2)`, "duplicated argument for parameter 'a'"},
+		{`fn foo(a, b) { (a, b) }; foo(x=2)`, "unknown keyword argument 'x' for function 'foo'"},
+		{`fn foo(a, b) { (a, b) }; foo(a=1, a=2)`, "duplicated keyword argument 'a'"},
+		{`fn foo(a, b) { (a, b) }; foo(a=1, 2)`, "positional argument follows keyword argument"},
+		{`fn foo(a, b) { (a, b) }; foo(a=1)`, "missing arguments for parameter 'b'"},
+		{`fn foo(...rest) { rest }; foo(rest=1)`, "spread parameter 'rest' cannot receive a keyword argument"},
+		{`List(default=1)`, "type 'List' does not accept keyword arguments"},
+		{`
+			data P {
+				x = 1
+				y = 2
+			}
+			P(y=3)
+		`, "type 'P' does not accept keyword arguments"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
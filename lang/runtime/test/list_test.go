@@ -0,0 +1,47 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListFunctions(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`a := List { 3, 1, 2 }; a.sort(); a`, "[1, 2, 3]"},
+		{`List { 3, 1, 2 }.sorted(false, true)`, "[3, 2, 1]"},
+		{`List { 'bb', 'a', 'ccc' }.sorted(fn(x) { len(x) })`, "[a, bb, ccc]"},
+		{`List { 'b1', 'a', 'b2', 'c' }.sorted(fn(x) { len(x) })`, "[a, c, b1, b2]"},
+		{`a := List { 3, 1 }; b := a.sorted(); a`, "[3, 1]"},
+		{`
+			data P {
+				age = 0
+				on lt(this, other) { this.age < other.age }
+			}
+			List { P { age: 3 }, P { age: 1 }, P { age: 2 } }.sorted() | map x: x.age
+		`, "[1, 2, 3]"},
+		{`a := List { 1, 2 }; a.insert(1, 9, 8); a`, "[1, 9, 8, 2]"},
+		{`a := List { 1, 2, 1 }; a.remove(1); a`, "[2, 1]"},
+		{`List { 1, 2 }.remove(3)`, "false"},
+		{`List { 1, 2 }.indexOf(2)`, "1"},
+		{`List { 1, 2 }.indexOf(5)`, "-1"},
+		{`List { 'x' }.contains('x')`, "true"},
+		{`List { 'x' }.contains('y')`, "false"},
+		{`List { 1, 2, 3 }.reverse()`, "[3, 2, 1]"},
+		{`List { 1 }.extend(range(3))`, "[1, 0, 1, 2]"},
+		{`List {}.extend(Dict { a: 1 })`, "[(a, 1)]"},
+		{`List { 1, 2, 3, 4 }.slice(1)`, "[2, 3, 4]"},
+		{`List { 1, 2, 3, 4 }.slice(1, -1)`, "[2, 3]"},
+		{`List { 1, 3, 5, 7 }.binarySearch(5)`, "2"},
+		{`List { 1, 3, 5, 7 }.binarySearch(4)`, "-1"},
+		{`a := List { 1 }; b := a.copy(); b.push(2); a`, "[1]"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
@@ -0,0 +1,129 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoopLabels(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`
+			found := false
+			outer: pipe range(1, 10) as a {
+				pipe range(a, 10) as b {
+					if a * b == 24 {
+						found = (a, b)
+						break outer
+					}
+				}
+			}
+			found
+		`, "(3, 8)"},
+		{`
+			visited := List {}
+			rows: pipe range(3) as a {
+				pipe range(3) as b {
+					if b == 1 { continue rows }
+					visited.push((a, b))
+				}
+			}
+			visited
+		`, "[(0, 0), (1, 0), (2, 0)]"},
+		{`
+			i := 0
+			n := 0
+			outer: for i < 3 {
+				i += 1
+				j := 0
+				for j < 3 {
+					j += 1
+					if j == 2 { continue outer }
+					n += 1
+				}
+			}
+			n
+		`, "3"},
+		{`
+			total := 0
+			outer: for {
+				pipe range(5) as i {
+					for {
+						if i == 3 { break outer }
+						total += i
+						break
+					}
+				}
+			}
+			total
+		`, "3"},
+		{`r := outer: for { pipe range(3) as i { if i == 2 { break outer i * 10 } } }; r`, "20"},
+		{`
+			fn f() {
+				l: for {
+					g := x => { pipe range(3) as l { if l == 1 { break l } } }
+					break l g(0)
+				}
+			}
+			f()
+		`, "1"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestLoopExpressions(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`i := 0; for i < 10 { i += 1; if i * i > 20 { break i } }`, "5"},
+		{`i := 0; r := for i < 10 { i += 1; if i * i > 20 { break i } }; r`, "5"},
+		{`r := for { break }; r`, "false"},
+		{`r := pipe List {1, 2, 3} as x { if x == 2 { break x * 10 } }; r`, "20"},
+		{`r := pipe List {1, 2, 3} as x { if x == 2 { break 'found' } } else { 'missing' }; r`, "found"},
+		{`r := pipe List {1, 2, 3} as x { if x == 5 { break 'found' } } else { 'missing' }; r`, "missing"},
+		{`r := for false { } else { 'no break' }; r`, "no break"},
+		{`i := 0; r := for i < 3 { i += 1; if i == 2 { continue } } else { i }; r`, "3"},
+		{`fn f() { pipe range(2) as a { yield a } else { yield 'else' } }; f() | to List`, "[0, 1, else]"},
+		{`fn f() { for true { return 1 } else { return 2 } }; f()`, "1"},
+		{`
+			log := List {}
+			outer: pipe range(2) as a {
+				pipe range(2) as b {
+					if b == 1 { continue outer }
+				} else {
+					log.push('inner else')
+				}
+			} else {
+				log.push('outer else')
+			}
+			log
+		`, "[outer else]"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestLoopLabelErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`a: for { a: for { break a } }`, "duplicated loop label 'a'"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const parseFn = `
+fn parse(s) {
+	if s == 'bad' { raise 'invalid ' .. s }
+	return Number(s)
+}
+`
+
+func TestTry(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`fn f() { v := try parse('2'); v * 10 }; f()`, "20"},
+		{`fn f() { v := try parse('bad'); v * 10 }; f()`, "<Maybe:error>"},
+		{`fn f() { v := try parse('bad'); v * 10 }; f().isErr()`, "true"},
+		{`fn f() { (try parse('bad')) + 1 }; f()`, "<Maybe:error>"},
+		{`fn f() { try parse('bad'); return 1 }; f()`, "<Maybe:error>"},
+		{`fn f() { l := List {}; l.push(try parse('bad')); l }; f()`, "<Maybe:error>"},
+		{`fn f() { try parse('2')? }; f()`, "2"},
+		{`fn f() { try parse('bad')? }; f()`, "<Maybe:error>"},
+		{`fn f() { try Error('boom') }; f()`, "<Maybe:error>"},
+		{`fn f(s) { try parse(s) }; fn g(s) { (try f(s)) * 2 }; (g('3'), g('bad'))`, "(6, <Maybe:error>)"},
+		{`fn f() { try parse('bad') }; f() ?? 'default'`, "default"},
+		{`fn f() { try parse('bad') }; m := f(); m!.message`, "invalid bad"},
+		{`try parse('4')`, "4"},
+		{`
+			calls := List {}
+			fn side() { calls.push(1); 1 }
+			fn f() { (try parse('bad')) + side() }
+			(f().isErr(), len(calls))
+		`, "(true, 0)"},
+		{`
+			ran := List {}
+			fn f() { if try parse('bad') { ran.push(1) }; 2 }
+			(f().isErr(), len(ran))
+		`, "(true, 0)"},
+		{`
+			calls := List {}
+			fn side() { calls.push(1); 1 }
+			fn f() { (try parse('bad'), side()) }
+			(f().isErr(), len(calls))
+		`, "(true, 0)"},
+		{`
+			calls := List {}
+			fn side() { calls.push(1); 1 }
+			fn f() { List { try parse('bad'), side() } }
+			(f().isErr(), len(calls))
+		`, "(true, 0)"},
+		{`
+			calls := List {}
+			fn side() { calls.push(1); 1 }
+			fn f() { Dict { a: try parse('bad'), b: side() } }
+			(f().isErr(), len(calls))
+		`, "(true, 0)"},
+		{`List {'1', 'bad'} | map x: try parse(x) | to List`, "[1, <Maybe:error>]"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(parseFn + c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestTryErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`try parse('bad')`, "invalid bad"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(parseFn + c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
+
+func TestMaybe(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`m := parse('2')?; (m.isOk(), m.isErr())`, "(true, false)"},
+		{`m := parse('bad')?; (m.isOk(), m.isErr())`, "(false, true)"},
+		{`parse('2')?.unwrapOr(0)`, "2"},
+		{`parse('bad')?.unwrapOr(0)`, "0"},
+		{`parse('2')?.map(x => x * 10).unwrapOr(0)`, "20"},
+		{`parse('bad')?.map(x => x * 10).unwrapOr(-1)`, "-1"},
+		{`parse('2')?.map(x => parse('bad')).isErr()`, "true"},
+		{`parse('2')?.andThen(x => parse(String(x + 1))).unwrapOr(0)`, "3"},
+		{`parse('2')?.andThen(x => parse('bad')).isErr()`, "true"},
+		{`parse('2')?.andThen(x => x + 1).unwrapOr(0)`, "3"},
+		{`parse('bad')?.andThen(x => x + 1).isErr()`, "true"},
+		{`parse('bad')?.orElse(e => e.message).unwrapOr('')`, "invalid bad"},
+		{`parse('bad')?.orElse(e => parse('bad')).isErr()`, "true"},
+		{`parse('2')?.orElse(e => 0).unwrapOr(-1)`, "2"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(parseFn + c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestMaybePipe(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`List {'1', 'bad', '3'} | map x: parse(x)? | oks | to List`, "[1, 3]"},
+		{`List {'1', 'bad', '3', 'bad'} | map x: parse(x)? | errors | map e: e.message | to List`, "[invalid bad, invalid bad]"},
+		{`List {'1', '3'} | map x: parse(x)? | errors | to List`, "[]"},
+		{`List {1, Error('boom'), 2} | errors | map e: e.message | to List`, "[boom]"},
+		{`List {1, Error('boom'), 2} | oks | to List`, "[1, 2]"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(parseFn + c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
@@ -0,0 +1,84 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReflectedOperators(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`
+			data Vec {
+				x = 0
+				y = 0
+				on mul(this, k) { return Vec { x: this.x * k, y: this.y * k } }
+				on rmul(this, k) { return this * k }
+				on repr(this) { return (this.x, this.y) }
+			}
+			v := Vec { x: 1, y: 2 }
+			(v * 3, 3 * v)
+		`, "((3, 6), (3, 6))"},
+		{`
+			data Money {
+				cents = 0
+				on radd(this, other) { return Money { cents: this.cents + other * 100 } }
+				on rsub(this, other) { return Money { cents: other * 100 - this.cents } }
+				on rdiv(this, other) { return other / this.cents }
+				on rintDiv(this, other) { return other // this.cents }
+				on rmod(this, other) { return other % this.cents }
+				on rpow(this, other) { return other ** this.cents }
+			}
+			m := Money { cents: 2 }
+			((1 + m).cents, (1 - m).cents, 6 / m, 7 // m, 7 % m, 3 ** m)
+		`, "(102, 98, 3, 3, 1, 9)"},
+		{`
+			data Unit {
+				name = ''
+				on radd(this, other) { return other + this.name }
+			}
+			'10' + Unit { name: 'kg' }
+		`, "10kg"},
+		{`
+			data Meter {
+				v = 0
+				on radd(this, other) { return 'meter' }
+			}
+			data Foot {}
+			(Foot {} + Meter {}, List {1} + Meter {}, true + Meter {}, Set {1} + Meter {})
+		`, "(meter, meter, meter, meter)"},
+		{`
+			data A {
+				on add(this, other) { return 'left' }
+				on radd(this, other) { return 'right' }
+			}
+			A {} + A {}
+		`, "left"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestReflectedOperatorErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`data V { on mul(this, k) { return 1 } }; 2 * V {}`, "invalid operation with incompatible types: 'Number' * 'V'"},
+		{`data V { on rmul(this, k) { return 1 } }; 2 + V {}`, "invalid operation with incompatible types: 'Number' + 'V'"},
+		{`data V {}; V {} - 1`, "type 'V' does not implement action 'sub'"},
+		{`List {1} * 2`, "type 'List' does not implement operator 'mul'"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
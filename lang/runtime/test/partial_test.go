@@ -0,0 +1,62 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartialApplication(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`fn add(a, b) { a + b }; inc := add(1, _); inc(2)`, "3"},
+		{`fn sub(a, b) { a - b }; f := sub(_, _); f(5, 3)`, "2"},
+		{`fn f(a, ...rest) { (a, rest) }; g := f(_, 1); g(0, 2, 3)`, "(0, [1, 2, 3])"},
+		{`atLeast3 := math.max(_, 3); atLeast3(1)`, "3"},
+		{`l := List {}; push := l.push(_); push(1); push(2); l`, "[1, 2]"},
+		{`fn f(a, b=1, c=2) { (a, b, c) }; g := f(_, c=_); g(1, 3)`, "(1, 1, 3)"},
+		{`fn f(a, b=1, c=2) { (a, b, c) }; g := f(_); g(1, c=9)`, "(1, 1, 9)"},
+		{`fn f(a, b=1, c=2) { (a, b, c) }; g := f(_, c=5); g(1, 2)`, "(1, 2, 5)"},
+		{`
+			fn clamp(x, lo, hi) {
+				if x < lo { return lo }
+				if x > hi { return hi }
+				return x
+			}
+			List { -5, 5, 50 } | map clamp(_, 0, 10)
+		`, "[0, 5, 10]"},
+		{`range(3) | map math.max(_, 1) | filter x: x > 1`, "[2]"},
+		{`
+			data P {
+				on call(this, a, b) { a * b }
+			}
+			p := P()
+			double := p(_, 2)
+			double(4)
+		`, "8"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestPartialApplicationErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`fn f(a, b) { a + b }; g := f(_, _); g(1)`, "partial function 'f' expects 2 arguments, 1 given"},
+		{`fn f(a, b) { a + b }; g := f(_, 1); g(a=1)`, "partial function 'f' expects 1 arguments, 0 given"},
+		{`fn f(a, b) { a + b }; g := f(_, 1); g(1, a=1)`, "duplicated argument for parameter 'a'"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
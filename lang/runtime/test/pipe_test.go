@@ -0,0 +1,65 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeFunctions(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`range(6) | zip(range(6)) | map a, b: a*b`, "[0, 1, 4, 9, 16, 25]"},
+		{`range(3) | zip(List { 'a', 'b' })`, "[(0, a), (1, b)]"},
+		{`List { 'a', 'b' } | enumerate(1)`, "[(index: 1, value: a), (index: 2, value: b)]"},
+		{`d := List { 'a', 'b' } | enumerate | to Dict; (len(d), d[0], d[1])`, "(2, a, b)"},
+		{`range(2) | chain(List { 5, 6 }, range(1))`, "[0, 1, 5, 6, 0]"},
+		{`range(3) | flatMap x: range(x)`, "[0, 0, 1]"},
+		{`List { List { 1 }, List { 2, 3 } } | flatMap`, "[1, 2, 3]"},
+		{`range(6) | skip(2)`, "[2, 3, 4, 5]"},
+		{`range(6) | skipWhile x: x < 3`, "[3, 4, 5]"},
+		{`range(7) | chunk(3)`, "[[0, 1, 2], [3, 4, 5], [6]]"},
+		{`List { 1, 2, 1, 3, 2 } | distinct`, "[1, 2, 3]"},
+		{`range(6) | distinct x: x % 3`, "[0, 1, 2]"},
+		{`range(6) | groupBy x: x % 2`, "[(key: 0, items: [0, 2, 4]), (key: 1, items: [1, 3, 5])]"},
+		{`range(6) | partition x: x % 2 == 0`, "[[0, 2, 4], [1, 3, 5]]"},
+		{`range(5) | scan acc, x: acc + x`, "[0, 1, 3, 6, 10]"},
+		{`range(10) | count x: x % 3 == 0`, "[4]"},
+		{`List {} | count`, "[0]"},
+		{`range(5) | any x: x > 3`, "[true]"},
+		{`range(5) | all x: x > 3`, "[false]"},
+		{`List { 3, 1, 2 } | sortBy`, "[1, 2, 3]"},
+		{`List { 'bb', 'a', 'cc' } | sortBy x: len(x)`, "[a, bb, cc]"},
+		{`List { 'bb', 'a', 'ccc' } | sortBy(true) x: len(x)`, "[ccc, bb, a]"},
+		{`range(4) | reverse`, "[3, 2, 1, 0]"},
+		{`range(3) | cycle(2)`, "[0, 1, 2, 0, 1, 2]"},
+		{`range(3) | cycle | take(7)`, "[0, 1, 2, 0, 1, 2, 0]"},
+		{`range(3) | interleave(List { 'a' }, List { 'x', 'y' })`, "[0, a, x, 1, y, 2]"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestPipeFunctionsErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`List { 1, 2 } | reverse(5)`, "reverse does not accept additional parameters"},
+		{`List { 1, 2 } | enumerate(1, 2)`, "enumerate does not accept additional parameters"},
+		{`List { 1, 2 } | skip(1, 2)`, "skip does not accept additional parameters"},
+		{`List { 1, 2 } | sortBy(true, 1) x: x`, "sortBy does not accept additional parameters"},
+		{`List { 1, 2 } | groupBy(1) x: x`, "groupBy does not accept additional parameters"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPretty(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`pretty(List {1, 2, 3})`, "[1, 2, 3]"},
+		{`pretty(List {List {1, 2}, List {3, 4}}, 2, -1, 10)`, "[\n  [1, 2],\n  [3, 4],\n]"},
+		{`pretty(Dict {b: List {1, 2, 3}, a: 1}, 4, -1, 18)`, "{\n    a: 1,\n    b: [1, 2, 3],\n}"},
+		{`pretty(List {1, List {2, List {3}}}, 2, 1)`, "[1, [...]]"},
+		{`pretty((1,))`, "(1,)"},
+		{`a := List {1}; a.push(a); pretty(a)`, "[1, <...>]"},
+		{`a := List {1}; b := Dict {a: a}; a.push(b); pretty(b)`, "{a: [1, <...>]}"},
+		{`a := List {1}; a.push(a); a`, "[1, <...>]"},
+		{`a := List {1}; a.push(a); (a, Dict {k: a}, a)`, "([1, <...>], {k: [1, <...>]}, [1, <...>])"},
+		{`s := List {}; pretty(List {s, s})`, "[[], []]"},
+		{`l := range(105) | to List; len(pretty(l, 2, -1, -1))`, "402"},
+		{`pretty(range(103) | to List, 2, -1, -1)[-11:]`, "... 3 more]"},
+		{`
+			data P {
+				x = 1
+				y = List {}
+			}
+			pretty(P {})
+		`, "P {x: 1, y: []}"},
+		{`
+			data P {
+				x = 1
+				on repr(this) { return 'P!' }
+			}
+			pretty(List {P {}})
+		`, "[P!]"},
+		{`
+			data Node {
+				next = false
+				on repr(this) { return 'Node(' + pretty(this.next) + ')' }
+			}
+			n := Node {}
+			n.next = n
+			n
+		`, "Node(<...>)"},
+		{`
+			enum Tree { Leaf(value), Branch(left, right) }
+			pretty(Tree.Branch(Tree.Leaf(1), Tree.Leaf(2)), 2, -1, 20)
+		`, "Tree.Branch(\n  Tree.Leaf(1),\n  Tree.Leaf(2),\n)"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestPrettyErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`pretty(1, 'a')`, "Expecting argument at index '1' to be a 'Number', got 'String'"},
+		{`pretty(1, -1)`, "pretty indent must not be negative, got -1"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
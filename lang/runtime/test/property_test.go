@@ -0,0 +1,362 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPropertyValidators(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`
+			data Person {
+				name = ''
+				age = 0 where age >= 0
+			}
+			p := Person { age: 3 }
+			p.age = 4
+			p.age
+		`, "4"},
+		{`
+			data Range {
+				min = 0
+				max = 10 where max >= this.min
+			}
+			r := Range { min: 2, max: 5 }
+			(r.min, r.max)
+		`, "(2, 5)"},
+		{`
+			limit := 5
+			data Bounded {
+				value = 0 where value <= limit
+			}
+			Bounded { value: 5 }.value
+		`, "5"},
+		{`
+			data Person {
+				age = 0 where age >= 0
+			}
+			fn create() { Person { age: -1 } }
+			fn f() { try create() }
+			e := f()!
+			(e.type, e.field)
+		`, "(Person, age)"},
+		{`
+			data Base { x = 1 where x > 0 }
+			data Child like Base { y = 2 }
+			fn create() { Child { x: 0 } }
+			fn f() { try create() }
+			f().isErr()
+		`, "true"},
+		{`
+			data Person {
+				age = 0 where age >= 0
+			}
+			p := Person {}
+			setField(p, 'age', 7)
+			p.age
+		`, "7"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestPropertyValidatorsErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`
+			data Person {
+				age = 0 where age >= 0
+			}
+			Person { age: -1 }
+		`, "invalid value -1 for field 'age' of type 'Person'"},
+		{`
+			data Person {
+				age = 0 where age >= 0
+			}
+			p := Person {}
+			p.age = -2
+		`, "invalid value -2 for field 'age' of type 'Person'"},
+		{`
+			data Person {
+				age = -1 where age >= 0
+			}
+			Person {}
+		`, "invalid value -1 for field 'age' of type 'Person'"},
+		{`
+			data Person {
+				age = 0 where age >= 0
+			}
+			p := Person {}
+			setField(p, 'age', -3)
+		`, "invalid value -3 for field 'age' of type 'Person'"},
+		{`
+			data Range {
+				min = 0
+				max = 10 where max >= this.min
+			}
+			Range { min: 20 }
+		`, "invalid value 10 for field 'max' of type 'Range'"},
+		{`
+			data Person {
+				age = 0 where age >= 0
+			}
+			data Employee like Person { id = 0 }
+			e := Employee {}
+			e.age = -1
+		`, "invalid value -1 for field 'age' of type 'Employee'"},
+		{`
+			data Person {
+				age = 0 where unknown
+			}
+			Person {}
+		`, "unidentified variable 'unknown'"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
+
+func TestComputedProperties(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`
+			data Rect {
+				w = 1
+				h = 1
+				get area(this) { this.w * this.h }
+			}
+			r := Rect { w: 2, h: 3 }
+			a := r.area
+			r.w = 4
+			(a, r.area)
+		`, "(6, 12)"},
+		{`
+			data Rect {
+				w = 1
+				h = 1
+				get area(this) { this.w * this.h }
+			}
+			data Square like Rect {
+				get side(this) { this.w }
+			}
+			s := Square { w: 3, h: 3 }
+			(s.area, s.side, getField(s, 'area'))
+		`, "(9, 3, 9)"},
+		{`
+			data Name {
+				first = ''
+				last = ''
+				get full(this) { this.first .. ' ' .. this.last }
+			}
+			Name { first: 'Ada', last: 'Lovelace' }.full
+		`, "Ada Lovelace"},
+		{`
+			data Rect {
+				w = 1
+				get area(this) { this.w }
+			}
+			fields(Rect {})
+		`, "[w]"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestComputedPropertiesErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`
+			data Rect {
+				w = 1
+				get area(this) { this.w }
+			}
+			r := Rect {}
+			r.area = 3
+		`, "field 'area' of type 'Rect' is read-only"},
+		{`
+			data Rect {
+				w = 1
+				get area(this) { this.w }
+			}
+			Rect { area: 3 }
+		`, "field 'area' of type 'Rect' is read-only"},
+		{`
+			data Rect {
+				w = 1
+				get area(this) { this.w }
+			}
+			setField(Rect {}, 'area', 3)
+		`, "field 'area' of type 'Rect' is read-only"},
+		{`
+			data Rect {
+				area = 1
+				get area(this) { 2 }
+			}
+		`, "variable 'area' is already defined"},
+		{`
+			data Rect {
+				get area() { 2 }
+			}
+		`, "computed property 'area' must have only a 'this' parameter"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
+
+func TestPrivateFields(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`
+			data Account {
+				_balance = 0
+				fn deposit(this, v) { this._balance += v; this }
+				fn balance(this) { this._balance }
+			}
+			Account {}.deposit(5).deposit(2).balance()
+		`, "7"},
+		{`
+			data Account {
+				_balance = 0
+				fn opened() { Account { _balance: 3 } }
+				fn balance(this) { this._balance }
+			}
+			Account.opened().balance()
+		`, "3"},
+		{`
+			data Counter {
+				_n = 0
+				fn _step(this) { this._n += 1 }
+				fn run(this, k) {
+					range(k) | each _: this._step()
+					this._n
+				}
+			}
+			Counter {}.run(3)
+		`, "3"},
+		{`
+			data Base {
+				_x = 1
+				fn x(this) { this._x }
+			}
+			data Child like Base {}
+			Child {}.x()
+		`, "1"},
+		{`
+			data Account {
+				_balance = 0
+				owner = 'ann'
+			}
+			a := Account {}
+			(fields(a), fields(Account), hasField(a, '_balance'))
+		`, "([owner], [owner], false)"},
+		{`
+			data Account {
+				_balance = 0
+				owner = 'ann'
+				fn inner(this) { (fields(this), hasField(this, '_balance')) }
+			}
+			Account {}.inner()
+		`, "([_balance, owner], true)"},
+		{`
+			data Account {
+				_balance = 0
+				owner = 'ann'
+			}
+			pretty(Account {})
+		`, "Account {owner: ann}"},
+		{`
+			data Account {
+				_balance = 0
+				fn get(this, name) { getField(this, name) }
+			}
+			Account {}.get('_balance')
+		`, "0"},
+		{`
+			data Account { _balance = 0 }
+			fn peek() { Account {}._balance }
+			fn f() { try peek() }
+			e := f()!
+			(e.type, e.field)
+		`, "(Account, _balance)"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestPrivateFieldsErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`
+			data Account { _balance = 0 }
+			Account {}._balance
+		`, "field '_balance' of type 'Account' is private"},
+		{`
+			data Account { _balance = 0 }
+			a := Account {}
+			a._balance = 10
+		`, "field '_balance' of type 'Account' is private"},
+		{`
+			data Account { _balance = 0 }
+			Account { _balance: 10 }
+		`, "field '_balance' of type 'Account' is private"},
+		{`
+			data Account {
+				_balance = 0
+				fn _reset(this) { this._balance = 0 }
+			}
+			Account {}._reset()
+		`, "field '_reset' of type 'Account' is private"},
+		{`
+			data Account { _balance = 0 }
+			getField(Account {}, '_balance')
+		`, "field '_balance' of type 'Account' is private"},
+		{`
+			data Account { _balance = 0 }
+			data Other {
+				fn peek(this, a) { a._balance }
+			}
+			Other {}.peek(Account {})
+		`, "field '_balance' of type 'Account' is private"},
+		{`
+			data Base { _x = 1 }
+			data Child like Base {
+				fn x(this) { this._x }
+			}
+			Child {}.x()
+		`, "field '_x' of type 'Child' is private"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
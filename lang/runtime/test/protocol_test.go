@@ -0,0 +1,109 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProtocol(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`
+			protocol Shape { fn area(this); on string(this) }
+			data Square is Shape {
+				side = 1
+				fn area(this) { return this.side ** 2 }
+				on string(this) { return 'square' }
+			}
+			s := Square { side: 3 }
+			(s.area(), s is Shape, 1 is Shape, isInstance(s, Shape), Shape)
+		`, "(9, true, false, true, <Type:Shape>)"},
+		{`
+			protocol Named {
+				fn name(this)
+			}
+			data Dog { fn name(this) { return 'dog' } }
+			data Rock {}
+			(Dog {} is Named, Rock {} is Named)
+		`, "(true, false)"},
+		{`
+			protocol A { fn a(this) }
+			protocol B like A { fn b(this) }
+			data X is B {
+				fn a(this) { return 'a' }
+				fn b(this) { return 'b' }
+			}
+			(X {} is A, X {} is B)
+		`, "(true, true)"},
+		{`
+			protocol Factory { fn create() }
+			data Point is Factory { fn create() { return Point {} } }
+			Point is Point
+		`, "false"},
+		{`
+			protocol HasArea { fn area(this) }
+			data Circle { fn area(this) { return 3 } }
+			describe := v => match v {
+				HasArea: 'shape'
+				Number: 'number'
+				_: 'other'
+			}
+			(describe(Circle {}), describe(1), describe('a'))
+		`, "(shape, number, other)"},
+		{`(List {} is Iterable, range(3) is Iterable, 'a' is Sized, 1 is Sized, print is Callable, Number is Callable)`, "(true, true, true, false, true, true)"},
+		{`(1 is Iterable, true is Iterable, isInstance(1, Iterable))`, "(false, false, false)"},
+		{`
+			data Bag {
+				on iter(this) { return iter(List {1, 2}) }
+				on len(this) { return 2 }
+			}
+			protocol Collection like Iterable, Sized {}
+			(Bag {} is Collection, List {} is Collection, range(2) is Collection, iter(Bag {}) | to List)
+		`, "(true, true, false, [1, 2])"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestProtocolErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`
+			protocol Shape { fn area(this) }
+			data Blob is Shape {}
+		`, "type 'Blob' does not implement protocol 'Shape', missing fn area"},
+		{`
+			protocol Stream {
+				fn open()
+				fn read(this)
+				on iter(this)
+			}
+			data File is Stream { fn read(this) { return '' } }
+		`, "missing fn open, on iter"},
+		{`
+			protocol A { fn a(this) }
+			protocol B like A { fn b(this) }
+			data X is B { fn b(this) { return 1 } }
+		`, "missing fn a"},
+		{`data X is Unknown {}`, "cannot find protocol 'Unknown'"},
+		{`data Y {}; data X is Y {}`, "'Y' is not a protocol"},
+		{`protocol P {}; P {}`, "protocol 'P' cannot be instantiated"},
+		{`protocol P { fn a(this); fn a(this) }`, "already defined"},
+		{`protocol P { fn a(this) { } }`, "invalid protocol definition"},
+		{`iter(print)`, "Expecting argument at index '0' to implement 'Iterable', got 'Function'"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandom(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`random.seed(42); a := random.number(); random.seed(42); a == random.number()`, "true"},
+		{`random.seed(7); a := String(random.sample(range(100), 5)); random.seed(7); a == String(random.sample(range(100), 5))`, "true"},
+		{`x := random.int(3, 3); x`, "3"},
+		{`x := random.int(1, 6); x >= 1 and x <= 6`, "true"},
+		{`x := random.number(); x >= 0 and x < 1`, "true"},
+		{`random.choice(List { 'a' })`, "a"},
+		{`random.choice(Dict { a: 1 })`, "(a, 1)"},
+		{`len(random.sample(Dict { a: 1, b: 2 }, 2))`, "2"},
+		{`len(random.shuffle(List { 1, 2, 3, 4 }))`, "4"},
+		{`len(random.sample(range(10), 3))`, "3"},
+		{`random.weighted(List { 'a', 'b' }, List { 0, 1 })`, "b"},
+		{`len(random.stream() | take(5))`, "5"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
@@ -0,0 +1,127 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReflection(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`(type(1), type('a'), type(List {}), type(false))`, "(<Type:Number>, <Type:String>, <Type:List>, <Type:Boolean>)"},
+		{`(type(1) == Number, type('a') == Number, type(fn() {}) == Function)`, "(true, false, true)"},
+		{`type(Number)`, "<Type:Type>"},
+		{`
+			data User {
+				name = 'anonymous'
+				age = 0
+
+				fn greet(this) { return 'hi ' .. this.name }
+				fn create() { return User {} }
+			}
+			u := User { name: 'bob' }
+			(type(u) == User, fields(u), fields(User), methods(u), methods(User))
+		`, "(true, [age, name], [age, name], [greet], [create, greet])"},
+		{`
+			data User { name = 'anonymous' }
+			u := User {}
+			(hasField(u, 'name'), hasField(u, 'age'), hasField(User, 'name'))
+		`, "(true, false, true)"},
+		{`
+			data User { name = 'anonymous' }
+			u := User {}
+			setField(u, 'name', 'bob')
+			(getField(u, 'name'), getField(u, 'age', 0), u.name)
+		`, "(bob, 0, bob)"},
+		{`
+			data Logged {
+				x = 0
+				on set(this, name, old, new) { return new * 10 }
+			}
+			l := Logged {}
+			setField(l, 'x', 2)
+			l.x
+		`, "20"},
+		{`(getField(1, 'x', 5), getField((a: 1), 'a', 5), getField((a: 1), 'b', 5))`, "(5, 1, 5)"},
+		{`
+			data P {
+				x = 1
+				on get(this, name, default) { name .. '!' }
+			}
+			(getField(P {}, 'y'), P {}.y)
+		`, "(y!, y!)"},
+		{`
+			data P {
+				x = 2
+				fn double(this) { this.x * 2 }
+			}
+			p := P {}
+			getField(p, 'double')(p)
+		`, "4"},
+		{`(type(1) == type(2), type(1) != Number, type(List {}) == List {}, hash(type(1)) == hash(Number))`, "(true, false, false, true)"},
+		{`(isInstance(1, Number), isInstance(1, String, Number), isInstance('a', Number, List))`, "(true, true, false)"},
+		{`data P {}; (isInstance(P {}, P), isInstance(P {}, Dict))`, "(true, false)"},
+		{`(callable(print), callable(x => x), callable(Number), callable(1), callable())`, "(true, true, true, false, false)"},
+		{`
+			data Adder { on call(this, x) { return x + 1 } }
+			(callable(Adder {}), callable(Adder))
+		`, "(true, true)"},
+		{`
+			fn f(a) {
+				b := 2
+				if true {
+					c := 3
+					return locals()
+				}
+			}
+			vars := f(1)
+			(vars['a'], vars['b'], vars['c'])
+		`, "(1, 2, 3)"},
+		{`fn f() { x := 1; return locals() }; f() | count`, "[1]"},
+		{`value := 42; fn f() { return globals() }; f()['value']`, "42"},
+		{`
+			fn inner() { return callstack() }
+			fn outer() { return inner() }
+			outer()
+		`, "[inner, outer, <global>]"},
+		{`
+			data Point {
+				x = 0
+				y = 0
+			}
+			fn describe(obj) {
+				return fields(obj) | map f: f .. '=' .. getField(obj, f) | to List
+			}
+			describe(Point { x: 1, y: 2 })
+		`, "[x=1, y=2]"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestReflectionErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`data P {}; getField(P {}, 'x')`, "does not have property 'x'"},
+		{`data P {}; setField(P {}, 'x', 1)`, "does not have property 'x'"},
+		{`data P {}; getField(P {}, 1)`, "to be a 'String'"},
+		{`type()`, "type requires a value"},
+		{`getField(1, 'x')`, "type 'Number' does not implement action 'get'"},
+		{`isInstance(1)`, "isInstance requires at least one type"},
+		{`isInstance(1, 2)`, "to be a 'Type'"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`Set { 1, 2, 2, 3 }`, "{1, 2, 3}"},
+		{`Set {}`, "{}"},
+		{`a := Set { 1, 2, 3 }; a.add(4); a.remove(1); a`, "{2, 3, 4}"},
+		{`Set { 1 }.add(1)`, "false"},
+		{`Set { 1, 2 }.has(2)`, "true"},
+		{`Set { 1, 2 }.has('2')`, "false"},
+		{`2 in Set { 1, 2 }`, "true"},
+		{`len(Set { 1, 1 })`, "1"},
+		{`Set { 1, 2 } + Set { 2, 3 }`, "{1, 2, 3}"},
+		{`Set { 1, 2 } - Set { 2, 3 }`, "{1}"},
+		{`Set { 1, 2 } * Set { 2, 3 }`, "{2}"},
+		{`Set { 1 } <= Set { 1, 2 }`, "true"},
+		{`Set { 1, 3 } <= Set { 1, 2 }`, "false"},
+		{`Set { 1, 2 } < Set { 1, 2 }`, "false"},
+		{`Set { 1, 2 } == Set { 2, 1 }`, "true"},
+		{`Set { 1, 2 } != Set { 1 }`, "true"},
+		{`List { 1, 2, 1, 3 } | to Set`, "{1, 2, 3}"},
+		{`Set { 1, 2, 3 } | map x: x*2`, "[2, 4, 6]"},
+		{`Set { List {1}, List {1}, (1, 2), (1, 2) }`, "{[1], (1, 2)}"},
+		{`l := List {1}; a := Set { l }; l.push(2); (a.has(List {1}), a.has(l))`, "(true, false)"},
+		{`
+			data P {
+				x = 0
+				y = 0
+				on hash(this) { return this.x }
+				on eq(this, other) { return this.x == other.x and this.y == other.y }
+			}
+			a := Set { P { x: 1, y: 1 }, P { x: 1, y: 2 }, P { x: 1, y: 3 }, P { x: 1, y: 1 } }
+			a.remove(P { x: 1, y: 1 })
+			(len(a), a.has(P { x: 1, y: 2 }), a.has(P { x: 1, y: 3 }), a.has(P { x: 1, y: 1 }))
+		`, "(2, true, true, false)"},
+		{`
+			data P {
+				x = 0
+				on repr(this) { return 'P' }
+			}
+			len(Set { P { x: 1 }, P { x: 2 } })
+		`, "2"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamedTuple(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`(min: 1, max: 9)`, "(min: 1, max: 9)"},
+		{`(x: 1)`, "(x: 1)"},
+		{`t := (min: 1, max: 9); (t.min, t.max, t[0], t[-1], t['max'], len(t))`, "(1, 9, 1, 9, 9, 2)"},
+		{`
+			t := (
+				name: 'a',
+				size: 2 * 3,
+			)
+			t.size
+		`, "6"},
+		{`t := (a: 1, b: 2, c: 3); (t[1:], t[0, 2])`, "((b: 2, c: 3), (a: 1, b: 2))"},
+		{`(a: 1, b: List {2}) == (a: 1, b: List {2})`, "true"},
+		{`((a: 1, b: 2) == (b: 1, a: 2), (a: 1, b: 2) == (1, 2), (1, 2) == (a: 1, b: 2), (a: 1) != (a: 2))`, "(true, true, true, true)"},
+		{`hash((a: 1, b: 2)) == hash((a: 1, b: 2))`, "true"},
+		{`hash((a: 1, b: 2)) == hash((1, 2))`, "true"},
+		{`d := Dict {}; d[(x: 1, y: 2)] = 'p'; d[(x: 1, y: 2)]`, "p"},
+		{`{lo, hi} := (lo: 1, hi: 9); hi - lo`, "8"},
+		{`{b, ...rest} := (a: 1, b: 2, c: 3); (b, rest)`, "(2, (a: 1, c: 3))"},
+		{`a, b := (a: 1, b: 2); a + b`, "3"},
+		{`fn stats(l) { (min: l[0], max: l[-1], count: len(l)) }; stats(List {1, 5, 9}).count`, "3"},
+		{`d := (min: 1, max: 9).toDict(); (d['min'], d['max'], len(d))`, "(1, 9, 2)"},
+		{`fields((b: 1, a: 2))`, "[b, a]"},
+		{`(a: 1, b: 2) | map x: x * 10 | to List`, "[10, 20]"},
+		{`t := deepCopy((a: List {1})); t.a.push(2); t`, "(a: [1, 2])"},
+		{`pretty((name: 'a', items: List {1, 2}), width=16)`, "(\n  name: a,\n  items: [1, 2],\n)"},
+		{`row := List {'a'} | enumerate | to List; (row[0].index, row[0].value)`, "(0, a)"},
+		{`g := range(4) | groupBy x: x % 2 | to List; (g[1].key, g[1].items)`, "(1, [1, 3])"},
+		{`rows := List {'a', 'b'} | enumerate | to List; (rows[0] == (0, 'a'), rows.contains((1, 'b')))`, "(true, true)"},
+		{`g := range(4) | groupBy x: x % 2 | to List; g[1] == (1, List {1, 3})`, "true"},
+		{`
+			row := List {'a'} | enumerate | to List
+			match row[0] {
+				(0, 'a'): 'first'
+				_: 'other'
+			}
+		`, "first"},
+		{`d := Dict {}; d[(0, 'a')] = 1; rows := List {'a'} | enumerate | to List; d[rows[0]]`, "1"},
+		{`len(Set {(0, 'a'), (index: 0, value: 'a')})`, "1"},
+		{`(len(Set {(a: 1, b: 2), (b: 1, a: 2)}), len(Set {(b: 1, a: 2), (1, 2), (a: 1, b: 2)}))`, "(1, 1)"},
+		{`d := Dict {}; d[(a: 1, b: 2)] = 'x'; (d[(b: 1, a: 2)], d[(1, 2)])`, "(x, x)"},
+		{`data Box { size = 0 }; (box: Box { size: 2 }).box.size`, "2"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestNamedTupleErrors(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`(a: 1, a: 2)`, "duplicated field 'a' in named tuple"},
+		{`(a: 1, 2)`, "named tuple fields must be given as 'name: value'"},
+		{`(a: 1).b`, "instance of type 'Tuple' does not have property 'b'"},
+		{`(a: 1)['b']`, "tuple does not have field 'b'"},
+		{`(1, 2).toDict()`, "only named tuples can be converted to a dict"},
+		{`{c} := (a: 1, b: 2)`, "does not have property 'c'"},
+		{`(a: x, b: y) := (1, 2)`, "cannot assign to a named tuple"},
+	}
+
+	for _, c := range cases {
+		_, err := lang.Eval([]byte(c.input))
+
+		assert.Error(t, err)
+		if err != nil {
+			assert.Contains(t, err.Error(), c.expected)
+		}
+	}
+}
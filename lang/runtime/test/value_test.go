@@ -0,0 +1,177 @@
+package test
+
+import (
+	"sht/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStructuralEquality(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`(List {1, 2} == List {1, 2}, List {1, 2} == List {2, 1}, List {1} != List {1, 2}, List {} == List {})`, "(true, false, true, true)"},
+		{`(Dict {a: 1, b: List {2}} == Dict {b: List {2}, a: 1}, Dict {a: 1} == Dict {a: 2}, Dict {a: 1} != Dict {b: 1})`, "(true, false, true)"},
+		{`((1, List {2}) == (1, List {2}), (1, 2) != (1, 3), List {1} == (1,), List {'1'} == List {1})`, "(true, true, false, false)"},
+		{`
+			a := List {1}
+			a.push(a)
+			b := List {1}
+			b.push(b)
+			(a == b, a == List {1, List {}})
+		`, "(true, false)"},
+		{`
+			data P {
+				x = 0
+				on eq(this, other) { return this.x == other.x }
+			}
+			(List {P { x: 1 }} == List {P { x: 1 }}, List {P { x: 1 }} == List {P { x: 2 }})
+		`, "(true, false)"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestHash(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`(hash(1) == hash(1), hash('a') == hash('a'), hash('1') == hash(1), hash(List {1, 2}) == hash(List {1, 2}))`, "(true, true, false, true)"},
+		{`(hash(Set {1, 2}) == hash(Set {2, 1}), hash(Dict {a: 1, b: 2}) == hash(Dict {b: 2, a: 1}), hash((1, 2)) == hash((2, 1)))`, "(true, true, false)"},
+		{`
+			data P {
+				x = 0
+				on hash(this) { return this.x }
+			}
+			data Q {}
+			q := Q {}
+			(hash(P { x: 3 }), hash(q) == hash(q), hash(Q {}) == hash(Q {}))
+		`, "(3, true, false)"},
+		{`a := List {1}; a.push(a); hash(a) == hash(a)`, "true"},
+		{`d := Dict {}; f := fn() { try d[List {1}] }; f(); (len(d), d)`, "(0, {})"},
+		{`k := List {1}; d := Dict {}; d.default = fn() { 0 }; d[k]; k.push(2); (d, d[List {1}])`, "({[1]: 0}, 0)"},
+		{`
+			d := Dict {}
+			d[List {1, 2}] = 'list'
+			d[(1, 'a')] = 'tuple'
+			d['x'] = 'string'
+			(d[List {1, 2}], d[(1, 'a')], d['x'], len(d))
+		`, "(list, tuple, string, 3)"},
+		{`
+			d := Dict {}
+			d[(1, 2)] = 'a'
+			List { k for k, v in d }
+		`, "[(1, 2)]"},
+		{`
+			data Point {
+				x = 0
+				y = 0
+				on hash(this) { return this.x * 100 + this.y }
+			}
+			d := Dict {}
+			d[Point { x: 1, y: 2 }] = 'p'
+			d[Point { x: 1, y: 2 }]
+		`, "p"},
+		{`d := List { (1, 2), (3, 4) } | to Dict; len(d)`, "2"},
+		{`
+			k := List {1}
+			d := Dict {}
+			d[k] = 'a'
+			k.push(2)
+			(d[List {1}], len(d), List { key for key, v in d })
+		`, "(a, 1, [[1]])"},
+		{`
+			data P {
+				x = 0
+				y = 0
+				on hash(this) { return this.x }
+				on eq(this, other) { return this.x == other.x and this.y == other.y }
+			}
+			d := Dict {}
+			d[P { x: 1, y: 1 }] = 'a'
+			d[P { x: 1, y: 2 }] = 'b'
+			d[P { x: 1, y: 1 }] = 'c'
+			(len(d), d[P { x: 1, y: 1 }], d[P { x: 1, y: 2 }])
+		`, "(2, c, b)"},
+		{`
+			data P {
+				x = 0
+				on hash(this) { return this.x % 2 }
+				on eq(this, other) { return this.x % 2 == other.x % 2 }
+			}
+			a := List { P { x: 1 }, P { x: 3 }, P { x: 2 } } | distinct | to List
+			g := List { P { x: 1 }, P { x: 3 }, P { x: 2 } } | groupBy p: p | to List
+			(len(a), len(g), len(g[0][1]))
+		`, "(2, 2, 2)"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
+
+func TestCopy(t *testing.T) {
+	cases := []struct{ input, expected string }{
+		{`
+			a := List {1, List {2}}
+			b := copy(a)
+			b.push(3)
+			b[1].push(4)
+			(a, b)
+		`, "([1, [2, 4]], [1, [2, 4], 3])"},
+		{`
+			a := List {1, List {2}}
+			b := deepCopy(a)
+			b[1].push(4)
+			(a, b, a == deepCopy(a))
+		`, "([1, [2]], [1, [2, 4]], true)"},
+		{`
+			a := Dict {x: List {1}}
+			b := deepCopy(a)
+			b['x'].push(2)
+			(a['x'], b['x'])
+		`, "([1], [1, 2])"},
+		{`
+			a := List {1}
+			a.push(a)
+			b := deepCopy(a)
+			b.push(5)
+			(b[1] == b, len(a), len(b))
+		`, "(true, 2, 3)"},
+		{`
+			shared := List {1}
+			b := deepCopy(List {shared, shared})
+			b[0].push(2)
+			b[1]
+		`, "[1, 2]"},
+		{`
+			data P { items = List {} }
+			p := P { items: List {1} }
+			c := copy(p)
+			d := deepCopy(p)
+			p.items.push(2)
+			(c.items, d.items, c == p)
+		`, "([1, 2], [1], false)"},
+		{`
+			data Conn {
+				id = 1
+				on copy(this) { return Conn { id: this.id + 1 } }
+			}
+			c := Conn {}
+			(copy(c).id, deepCopy(List {c})[0].id)
+		`, "(2, 2)"},
+		{`(copy(1), copy('a'), deepCopy((1, List {2})))`, "(1, a, (1, [2]))"},
+	}
+
+	for _, c := range cases {
+		result, err := lang.Eval([]byte(c.input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result)
+	}
+}
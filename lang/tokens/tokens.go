@@ -29,7 +29,7 @@ const (
 	Bang     = "bang"     // "!"
 	Question = "question" // "?"
 	At       = "at"       // "@"
-	Pipe     = "pipe"     // "|"
+	Pipe     = "pipe"     // "|", "|>"
 	Arrow    = "arrow"    // "=>"
 	Spread   = "spread"   // "..."
 